@@ -2,83 +2,155 @@
 package main
 
 import (
-	"github.com/aquaheyday/go-auth-service/internal/infra/mailer/mock"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"context"
+	"errors"
+	"fmt"
 	"google.golang.org/grpc/reflection"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"sync"
 	"time"
 
 	grpcdeliv "github.com/aquaheyday/go-auth-service/internal/delivery/grpc"
 	"github.com/aquaheyday/go-auth-service/internal/delivery/grpc/middleware" // 미들웨어 패키지 추가
+	httpdeliv "github.com/aquaheyday/go-auth-service/internal/delivery/http"
 	"github.com/aquaheyday/go-auth-service/internal/infra/cache"
+	"github.com/aquaheyday/go-auth-service/internal/infra/certs"
 	"github.com/aquaheyday/go-auth-service/internal/infra/db"
+	"github.com/aquaheyday/go-auth-service/internal/infra/health"
+	"github.com/aquaheyday/go-auth-service/internal/infra/mailer"
+	"github.com/aquaheyday/go-auth-service/internal/infra/proxyproto"
+	"github.com/aquaheyday/go-auth-service/internal/infra/ratelimit"
+	"github.com/aquaheyday/go-auth-service/internal/infra/sms"
 	postgresrepo "github.com/aquaheyday/go-auth-service/internal/repository/postgres"
 	redisrepo "github.com/aquaheyday/go-auth-service/internal/repository/redis"
+	tokenrepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
 	"github.com/aquaheyday/go-auth-service/internal/usecase"
 	"github.com/aquaheyday/go-auth-service/pkg/config"
+	"github.com/aquaheyday/go-auth-service/pkg/lifecycle"
 	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+	"github.com/aquaheyday/go-auth-service/pkg/token"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
 	"github.com/grpc-ecosystem/go-grpc-middleware" // 미들웨어 체인 패키지 추가
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc/filters"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	// 설정 파일 로드
-	cfg, err := config.LoadConfig()
+	// 설정 로드 - 기본값 < 설정 파일(--config) < 환경 변수 < 명령행 플래그
+	// 비밀 값은 file://(Docker/Kubernetes secret), env://, local://(secrets.file) 참조로 지정 가능
+	cfg, opts, err := config.LoadConfig(os.Args[1:])
+	if errors.Is(err, pflag.ErrHelp) {
+		return
+	}
 	if err != nil {
-		// 설정 로드 실패 시 즉시 종료
+		// 설정 로드 또는 검증 실패 시 즉시 종료 (모든 위반 사항을 함께 출력)
 		log.Fatalf("failed to load config: %v", err)
 	}
-
-	// LogLevel이 비어있는 경우 기본값 설정
-	if cfg.LogLevel == "" {
-		cfg.LogLevel = "info" // 기본값으로 info 레벨 설정
+	if opts.PrintConfig {
+		// 비밀 값을 가린 최종 설정을 출력하고 종료
+		if err := cfg.WriteRedacted(os.Stdout); err != nil {
+			log.Fatalf("failed to print config: %v", err)
+		}
+		return
 	}
 
 	// 로거(logger) 초기화
-	logg := logger.NewLogger(cfg.LogLevel)
+	logg := logger.NewLogger(cfg.Log.Level)
 	if logg == nil {
 		log.Fatal("failed to initialize logger")
 	}
-	defer logg.Sync() // 애플리케이션 종료 시 로그 버퍼 플러시
+	logg.Info("config loaded", zap.String("file", opts.File), zap.String("environment", cfg.Environment))
+	// production이 아니면 기본 비밀 값을 허용하되 경고 (production은 설정 검증에서 거부)
+	if keys := cfg.DefaultSecrets(); len(keys) > 0 {
+		logg.Warn("using built-in default secrets, do not use in production", zap.Strings("keys", keys))
+	}
+
+	// 로거를 주입받지 않는 패키지에서 logger.FromContext로 사용할 전역 로거 설정
+	zap.ReplaceGlobals(logg)
+
+	// 지연 시간 히스토그램 설정 (히스토그램은 처음 기록할 때 생성되므로 서버 시작 전에 적용)
+	metrics.ConfigureHistograms(metrics.HistogramConfig{
+		Buckets: cfg.Metrics.LatencyBuckets,
+		Native:  cfg.Metrics.NativeHistograms,
+	})
+
+	// 트레이싱 초기화 - W3C Trace Context 전파 및 설정된 익스포터로 스팬 전송
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:     cfg.Tracing.Exporter,
+		OTLPEndpoint: cfg.Tracing.OTLPEndpoint,
+		OTLPInsecure: cfg.Tracing.OTLPInsecure,
+		FilePath:     cfg.Tracing.File,
+		SampleRatio:  cfg.Tracing.SampleRatio,
+		ServiceName:  "auth-service",
+		Version:      "1.0.0",
+	})
+	if err != nil {
+		logg.Fatal("failed to initialize tracing", zap.Error(err))
+	}
+
+	// 라이프사이클 매니저 - SIGINT/SIGTERM 수신 시 아래에서 등록한 순서대로 종료
+	lc := lifecycle.New(logg, cfg.ShutdownTimeout)
 
 	// Postgres 데이터베이스 연결
-	postgresDbConn, err := db.NewPostgres(cfg.DatabaseURL)
+	postgresDbConn, err := db.NewPostgres(cfg.Database.URL)
 	if err != nil {
 		logg.Fatal("failed to connect to postgres", zap.Error(err))
 	}
-	defer postgresDbConn.Close() // 애플리케이션 종료 시 연결 해제
 
 	// Redis 캐시 클라이언트 생성
-	rdb := cache.NewRedis(cfg.RedisAddr)
-	defer rdb.Close() // 애플리케이션 종료 시 연결 해제
-
-	// 메일러(메일 발송) 인터페이스 초기화
-	/*mailSender := mailerSG.NewSendGridMailer(
-		cfg.SendGridAPIKey,
-		cfg.SendGridFromEmail,
-		cfg.SendGridFromName,
-		cfg.SendGridSandbox, // prod에선 false 권장
-	)*/
+	rdb := cache.NewRedis(cfg.Redis.Addr)
 
-	mailSender := mock.NewSMTPMailer("localhost", 1025, "user", "pass")
+	// 메일러(메일 발송) 초기화 - 설정(mail.primary, mail.secondary, mail.routes)에 따라 프로바이더 선택
+	mailSender, err := mailer.NewFromConfig(cfg, logg)
+	if err != nil {
+		logg.Fatal("failed to configure mailer", zap.Error(err))
+	}
 
 	// 레포지토리 및 유스케이스(비즈니스 로직) 구성
 	userRepo := postgresrepo.NewUserRepository(postgresDbConn)   // 사용자 저장소
 	verificationRepo := redisrepo.NewVerificationRepository(rdb) // 검증 코드 저장소
-	// 검증 코드 발송 및 확인 유스케이스
-	verifyUC := usecase.NewVerifyUseCase(verificationRepo, mailSender)
+	outboxRepo := redisrepo.NewOutboxRepository(rdb)             // 이메일 발송 대기열
+	// SMS 프로바이더 - twilio 설정이 없으면 비활성화 (휴대폰 인증 요청은 Unimplemented 응답)
+	var smsProvider sms.SMSProvider
+	if cfg.Twilio.AccountSID != "" {
+		smsProvider = sms.NewTwilioProvider(cfg.Twilio)
+	} else {
+		logg.Info("twilio not configured, phone verification disabled")
+	}
+	// 유스케이스 설정 - 기능 토글과 동시 세션 수 제한 (설정 재로드 시 교체)
+	settings := usecase.NewSettings(featuresFromConfig(cfg), sessionCapFromConfig(cfg))
+	// 검증 코드 발송 및 확인 유스케이스 (메일은 대기열에 적재만 함)
+	verifyUC := usecase.NewVerifyUseCase(verificationRepo, outboxRepo, smsProvider, settings)
 	// 회원가입 유스케이스
-	signupUC := usecase.NewSignupUseCase(userRepo, verificationRepo)
+	signupUC := usecase.NewSignupUseCase(userRepo, verificationRepo, settings)
 
 	// 토큰 레포지토리 생성 및 로그인 유스케이스 추가
-	tokenRepo := redisrepo.NewTokenRepository(rdb)          // 토큰 저장소 추가
-	loginUC := usecase.NewLoginUseCase(userRepo, tokenRepo) // 로그인 유스케이스 추가
+	tokenRepo := redisrepo.NewTokenRepository(rdb) // 토큰 저장소 추가
+	// 토큰 발급기 - 서명 키는 시작 시 고정, 발급 정책은 설정 재로드 시 교체
+	// 동시 세션 수 제한으로 종료된 세션의 액세스 토큰은 토큰 저장소의 폐기 목록으로 거부
+	tokenIssuer := token.NewIssuer([]byte(cfg.JWT.AccessSecret), []byte(cfg.JWT.RefreshSecret), tokenPoliciesFromConfig(cfg), tokenRepo)
+	loginUC := usecase.NewLoginUseCase(userRepo, tokenRepo, tokenIssuer, settings) // 로그인 유스케이스 추가
+	outboxUC := usecase.NewOutboxUseCase(outboxRepo)                               // 아웃박스 관리 유스케이스
+
+	// 신뢰하는 프록시 설정 - 이 대역에서 온 연결만 X-Forwarded-For, PROXY protocol 헤더로 클라이언트 IP를 판단
+	trustedProxies, err := proxyproto.ParsePrefixes(cfg.Proxy.Trusted)
+	if err != nil {
+		logg.Fatal("invalid proxy.trusted", zap.Error(err))
+	}
+	proxyCfg := proxyproto.Config{Mode: proxyproto.Mode(cfg.Proxy.Protocol), Trusted: trustedProxies}
 
-	// gRPC 서버 리스너 생성
-	lis, err := net.Listen("tcp", cfg.GRPCPort)
+	// gRPC 서버 리스너 생성 (proxy.protocol 설정 시 로드밸런서가 전달한 원래 클라이언트 주소 사용)
+	lis, err := listen(cfg.GRPC.Port, proxyCfg)
 	if err != nil {
 		logg.Fatal("failed to listen", zap.Error(err))
 	}
@@ -87,36 +159,370 @@ func main() {
 	// 1. Prometheus를 사용하여 gRPC 서버 메트릭 수집 미들웨어 생성
 	metricsInterceptor := middleware.MetricsInterceptor()
 
-	// 2. 속도 제한 미들웨어 생성 (초당 100 요청, 버스트 200, 1시간 TTL)
-	rateLimiter := middleware.NewRateLimiter(100, 200, 1*time.Hour)
+	// 2. 속도 제한 미들웨어 생성 (기본: 초당 100 요청, 버스트 200, 1시간 TTL, 최대 10만 클라이언트)
+	// rate_limit.backend가 redis이면 레플리카 간에 한도를 공유하고, Redis 장애 시 메모리 제한기로 대체
+	localLimiter := ratelimit.NewLocal(cfg.RateLimit.TTL, cfg.RateLimit.MaxClients)
+	limiter, err := ratelimit.New(cfg.RateLimit.Backend, localLimiter, rdb, ratelimit.RedisConfig{Timeout: cfg.RateLimit.RedisTimeout}, logg)
+	if err != nil {
+		logg.Fatal("failed to configure rate limiter", zap.Error(err))
+	}
+	// rate_limit.policy_file이 있으면 메서드별로 IP, 대상 이메일/전화번호, 사용자별 제한을 적용
+	policies, err := loadRatePolicies(cfg)
+	if err != nil {
+		logg.Fatal("failed to load rate limit policies", zap.Error(err))
+	}
+	rateLimiter := middleware.NewRateLimiter(limiter, policies, tokenIssuer, logg)
 
 	// gRPC 서버 인스턴스 및 핸들러 등록 - 미들웨어 체인 적용
 	server := grpcdeliv.NewGRPCServer(logg, verifyUC, signupUC, loginUC)
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(
-			grpc_middleware.ChainUnaryServer(
-				metricsInterceptor,                   // 메트릭 미들웨어
-				rateLimiter.RateLimiterInterceptor(), // 속도 제한 미들웨어
-			),
-		),
+	// gRPC 서버와 HTTP 게이트웨이가 같은 인터셉터 체인을 사용
+	// 접근 로그와 메트릭이 panic으로 인한 codes.Internal도 기록하도록 복구 미들웨어는 그 안쪽에 두고,
+	// 바깥쪽 미들웨어 자체의 panic이 프로세스를 종료시키지 않도록 가장 바깥쪽에도 복구 미들웨어를 둠
+	clientIPResolver := middleware.NewClientIPResolver(trustedProxies)
+	// AdminService는 admin.allowed_identities에 있는 mTLS 클라이언트만 호출 가능
+	adminAuth := middleware.NewAdminAuth(pb.AdminService_ServiceDesc.ServiceName, cfg.Admin.AllowedIdentities, logg)
+	unaryChain := grpc_middleware.ChainUnaryServer(
+		middleware.RecoveryInterceptor(logg),             // 바깥쪽 미들웨어의 panic 복구
+		middleware.RequestIDInterceptor(),                // 요청 ID 미들웨어 (로그, 메트릭, 에러에 사용)
+		middleware.ClientIPInterceptor(clientIPResolver), // 클라이언트 IP 확인 (로그, 속도 제한, 감사 기록에 사용)
+		middleware.AccessLogInterceptor(logg),            // 접근 로그 미들웨어
+		metricsInterceptor,                               // 메트릭 미들웨어
+		middleware.RecoveryInterceptor(logg),             // 핸들러와 안쪽 미들웨어의 panic 복구
+		adminAuth.Interceptor(),                          // 운영자 API 접근 제어
+		rateLimiter.RateLimiterInterceptor(),             // 속도 제한 미들웨어
+		middleware.ValidationInterceptor(),               // 요청 유효성 검사 미들웨어
 	)
+	// 스트리밍 RPC도 같은 순서의 미들웨어를 거치도록 스트림 체인을 함께 구성
+	streamChain := grpc_middleware.ChainStreamServer(
+		middleware.StreamRecoveryInterceptor(logg),
+		middleware.StreamRequestIDInterceptor(),
+		middleware.StreamClientIPInterceptor(clientIPResolver),
+		middleware.StreamAccessLogInterceptor(logg),
+		middleware.StreamMetricsInterceptor(),
+		middleware.StreamRecoveryInterceptor(logg),
+		adminAuth.StreamInterceptor(),
+		rateLimiter.StreamRateLimiterInterceptor(),
+		middleware.StreamValidationInterceptor(),
+	)
+	serverOpts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(unaryChain),
+		grpc.StreamInterceptor(streamChain),
+		// 수신 메타데이터의 traceparent를 이어받아 RPC마다 서버 스팬 생성 (헬스 체크 제외)
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(filters.Not(filters.HealthCheck())))),
+	}
+
+	// TLS 설정 - tls.cert_file이 있으면 TLS(필요 시 mTLS) 적용, 인증서는 파일 변경 시 자동 재로드
+	var certReloader *certs.Reloader
+	if cfg.TLS.CertFile != "" {
+		certReloader, err = certs.NewReloader(certs.Config{
+			CertFile:       cfg.TLS.CertFile,
+			KeyFile:        cfg.TLS.KeyFile,
+			ClientCAFile:   cfg.TLS.ClientCAFile,
+			ClientAuth:     certs.ClientAuthMode(cfg.TLS.ClientAuth),
+			ReloadInterval: cfg.TLS.ReloadInterval,
+		}, logg)
+		if err != nil {
+			logg.Fatal("failed to configure tls", zap.Error(err))
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certReloader.ServerConfig())))
+		logg.Info("gRPC TLS enabled", zap.String("client_auth", cfg.TLS.ClientAuth))
+	} else {
+		logg.Warn("gRPC TLS disabled, credentials are sent in plaintext")
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
 	grpcdeliv.RegisterGRPCServer(grpcServer, server)
+	grpcdeliv.RegisterAdminServer(grpcServer, grpcdeliv.NewAdminServer(logg, outboxUC))
+
+	// gRPC Health 서비스 등록 - Postgres, Redis 상태에 따라 SERVING/NOT_SERVING 전환
+	// AdminService는 아웃박스(Redis)만 사용하므로 Postgres 장애와 무관하게 SERVING 유지
+	healthChecker, err := health.NewChecker(
+		map[string][]string{
+			pb.AuthService_ServiceDesc.ServiceName:  {health.ProbePostgres, health.ProbeRedis},
+			pb.AdminService_ServiceDesc.ServiceName: {health.ProbeRedis},
+		},
+		[]health.Probe{health.PostgresProbe(postgresDbConn), health.RedisProbe(rdb)},
+		health.Config{},
+		logg,
+	)
+	if err != nil {
+		logg.Fatal("failed to configure health checks", zap.Error(err))
+	}
+	healthpb.RegisterHealthServer(grpcServer, healthChecker.Server())
 
 	// gRPC 리플렉션 서비스 등록
 	// 클라이언트에서 동적으로 서비스 정보를 조회할 수 있도록 함
 	reflection.Register(grpcServer)
 
-	// 메트릭 HTTP 서버 시작
+	// 설정 재로드 - 설정 파일 변경 또는 SIGHUP 시 다시 읽어 재시작 없이 반영 가능한 값을 적용
+	// 검증이나 구독자의 준비 단계가 실패하면 모든 구성 요소가 이전 설정을 유지
+	watcher := config.NewWatcher(cfg, opts, logg)
+	watcher.Subscribe("log level", func(c *config.Config) (func(), error) {
+		return func() { _ = logger.SetLevel(c.Log.Level) }, nil
+	})
+	watcher.Subscribe("rate limit policies", func(c *config.Config) (func(), error) {
+		policies, err := loadRatePolicies(c)
+		if err != nil {
+			return nil, err
+		}
+		return func() { rateLimiter.SetPolicies(policies) }, nil
+	})
+	watcher.Subscribe("mail routing", func(c *config.Config) (func(), error) {
+		defaults, routes := mailer.RoutingFromConfig(c)
+		if err := mailSender.CheckRouting(defaults, routes); err != nil {
+			return nil, err
+		}
+		return func() { _ = mailSender.SetRouting(defaults, routes) }, nil
+	})
+	watcher.Subscribe("token policy", func(c *config.Config) (func(), error) {
+		return func() { tokenIssuer.SetPolicies(tokenPoliciesFromConfig(c)) }, nil
+	})
+	watcher.Subscribe("session limit", func(c *config.Config) (func(), error) {
+		return func() { settings.SetSessionCap(sessionCapFromConfig(c)) }, nil
+	})
+	watcher.Subscribe("features", func(c *config.Config) (func(), error) {
+		return func() { settings.SetFeatures(featuresFromConfig(c)) }, nil
+	})
+
+	// 백그라운드 워커 시작 (헬스 프로브, 아웃박스 발송, 인증서 재로드, 속도 제한 클라이언트 정리, 활성 세션 집계, 설정 재로드)
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	if certReloader != nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			certReloader.Run(workerCtx)
+		}()
+	}
+	workers.Add(5)
 	go func() {
-		http.Handle("/metrics", promhttp.Handler())
-		if err := http.ListenAndServe(":9090", nil); err != nil {
-			logg.Error("Failed to start metrics server", zap.Error(err))
+		defer workers.Done()
+		healthChecker.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		watcher.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		localLimiter.Run(workerCtx)
+	}()
+	go func() {
+		defer workers.Done()
+		usecase.RunActiveSessionsGauge(workerCtx, tokenRepo, 30*time.Second, logg)
+	}()
+	outboxCfg := usecase.DefaultOutboxWorkerConfig()
+	outboxCfg.Workers = cfg.Outbox.Workers
+	outboxCfg.MaxAttempts = cfg.Outbox.MaxAttempts
+	outboxWorker := usecase.NewOutboxWorker(outboxRepo, mailSender, outboxCfg, logg)
+	go func() {
+		defer workers.Done()
+		if err := outboxWorker.Run(workerCtx); err != nil {
+			logg.Error("outbox worker stopped", zap.Error(err))
 		}
 	}()
 
+	// 메트릭 및 헬스 체크 HTTP 서버 (전역 DefaultServeMux 대신 전용 mux 사용)
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle(cfg.Metrics.Path, metrics.Handler())
+	metricsMux.Handle("/healthz", healthChecker.LivenessHandler()) // 프로세스 생존 여부
+	metricsMux.Handle("/readyz", healthChecker.ReadinessHandler()) // 의존성 포함 요청 처리 가능 여부
+	metricsServer := &http.Server{
+		Addr:              cfg.Metrics.Addr,
+		Handler:           metricsMux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	metricsLis, err := net.Listen("tcp", metricsServer.Addr)
+	if err != nil {
+		logg.Fatal("failed to listen for metrics", zap.Error(err))
+	}
+	lc.Go("metrics server", func() error { return serveHTTP(metricsServer, metricsLis) })
+
+	// REST/JSON 게이트웨이
+	var httpServer *http.Server
+	if cfg.HTTP.Port != "" {
+		gateway := httpdeliv.NewGateway(server, unaryChain, httpdeliv.CORSConfig{
+			AllowedOrigins:   cfg.CORS.AllowedOrigins,
+			AllowCredentials: cfg.CORS.AllowCredentials,
+		}, logg)
+		httpServer = &http.Server{
+			Addr:              cfg.HTTP.Port,
+			Handler:           gateway.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		// gRPC와 같은 인증서(자동 재로드 포함)를 사용
+		if certReloader != nil {
+			httpServer.TLSConfig = certReloader.ServerConfig()
+		} else {
+			logg.Warn("HTTP gateway TLS disabled, credentials are sent in plaintext")
+		}
+		httpLis, err := listen(cfg.HTTP.Port, proxyCfg)
+		if err != nil {
+			logg.Fatal("failed to listen for http gateway", zap.Error(err))
+		}
+		logg.Info("HTTP gateway running", zap.String("port", cfg.HTTP.Port), zap.Bool("tls", certReloader != nil))
+		lc.Go("http gateway", func() error { return serveHTTP(httpServer, httpLis) })
+	}
+
 	// gRPC 서버 실행
-	logg.Info("gRPC server running", zap.String("port", cfg.GRPCPort))
-	if err := grpcServer.Serve(lis); err != nil {
-		logg.Fatal("failed to serve grpc", zap.Error(err))
+	logg.Info("gRPC server running", zap.String("port", cfg.GRPC.Port))
+	lc.Go("grpc server", func() error { return grpcServer.Serve(lis) })
+
+	// 종료 순서 등록
+	// 1. 헬스 상태를 NOT_SERVING으로 전환해 로드밸런서가 새 요청을 보내지 않도록 함
+	lc.OnStop("health", func(ctx context.Context) error {
+		healthChecker.Shutdown()
+		return nil
+	})
+	// 로드밸런서가 NOT_SERVING을 반영할 때까지 새 요청을 계속 처리
+	if cfg.DrainDelay > 0 {
+		lc.OnStop("drain", lifecycle.Delay(cfg.DrainDelay))
+	}
+	// 2. 처리 중인 RPC 완료 대기 (deadline 초과 시 강제 종료)
+	lc.OnStop("grpc server", func(ctx context.Context) error {
+		return gracefulStop(ctx, grpcServer)
+	})
+	// 3. HTTP 서버 종료 (메트릭 서버는 마지막까지 /readyz 응답을 위해 나중에 종료)
+	if httpServer != nil {
+		lc.OnStop("http gateway", httpServer.Shutdown)
+	}
+	lc.OnStop("metrics server", metricsServer.Shutdown)
+	// 4. 백그라운드 워커 중지 후 메일러 정리
+	lc.OnStop("background workers", func(ctx context.Context) error {
+		stopWorkers()
+		return waitGroup(ctx, &workers)
+	})
+	lc.OnStop("mailer", func(ctx context.Context) error {
+		return mailSender.Close()
+	})
+	// 5. 남은 스팬 전송
+	lc.OnStop("tracing", shutdownTracing)
+	// 6. 저장소 연결 해제
+	lc.OnStop("postgres", func(ctx context.Context) error {
+		return postgresDbConn.Close()
+	})
+	lc.OnStop("redis", func(ctx context.Context) error {
+		return rdb.Close()
+	})
+
+	// 종료 신호 대기 후 등록된 순서대로 종료
+	shutdownErr := lc.Wait()
+	if shutdownErr != nil {
+		logg.Error("shutdown completed with errors", zap.Error(shutdownErr))
+	} else {
+		logg.Info("shutdown completed")
+	}
+	_ = logg.Sync() // 로그 버퍼 플러시
+	if shutdownErr != nil {
+		os.Exit(1)
+	}
+}
+
+// loadRatePolicies는 설정의 기본 제한(rate_limit.rps, burst)과 정책 파일로 메서드별 속도 제한 정책을 만듭니다.
+func loadRatePolicies(cfg *config.Config) (*ratelimit.PolicyTable, error) {
+	policies := ratelimit.DefaultPolicyTable(ratelimit.LimitSpec{Rate: cfg.RateLimit.RPS, Burst: cfg.RateLimit.Burst})
+	if cfg.RateLimit.PolicyFile == "" {
+		return policies, nil
+	}
+	return ratelimit.LoadPolicies(cfg.RateLimit.PolicyFile, policies.Default)
+}
+
+// featuresFromConfig는 설정의 기능 토글을 유스케이스 기능 목록으로 변환합니다.
+func featuresFromConfig(cfg *config.Config) usecase.Features {
+	return usecase.Features{
+		Signup:            cfg.Features.Signup,
+		PhoneVerification: cfg.Features.PhoneVerification,
+	}
+}
+
+// tokenPoliciesFromConfig는 설정의 jwt 구역을 토큰 발급 정책으로 변환합니다.
+func tokenPoliciesFromConfig(cfg *config.Config) token.Policies {
+	j := cfg.JWT
+	p := token.Policies{
+		Default: token.Policy{
+			AccessTTL:       j.AccessTTL,
+			RefreshTTL:      j.RefreshTTL,
+			SessionLifetime: j.SessionLifetime,
+			IdleTimeout:     j.IdleTimeout,
+			Issuer:          j.Issuer,
+			Audience:        j.Audience,
+		},
+		Clients: make(map[string]token.Policy, len(j.Clients)),
+	}
+	for id, c := range j.Clients {
+		p.Clients[id] = token.Policy{
+			AccessTTL:       c.AccessTTL,
+			RefreshTTL:      c.RefreshTTL,
+			SessionLifetime: c.SessionLifetime,
+			IdleTimeout:     c.IdleTimeout,
+			Issuer:          c.Issuer,
+			Audience:        c.Audience,
+			Secret:          c.Secret,
+		}
+	}
+	return p
+}
+
+// sessionCapFromConfig는 설정의 sessions 구역을 동시 세션 수 제한으로 변환합니다.
+func sessionCapFromConfig(cfg *config.Config) tokenrepo.SessionCap {
+	return tokenrepo.SessionCap{Max: cfg.Sessions.MaxPerUser, Policy: cfg.Sessions.OnLimit}
+}
+
+// serveHTTP는 HTTP 서버를 실행합니다 (TLSConfig가 있으면 TLS). Shutdown으로 종료된 경우 nil을 반환합니다.
+func serveHTTP(srv *http.Server, lis net.Listener) error {
+	var err error
+	if srv.TLSConfig != nil {
+		err = srv.ServeTLS(lis, "", "") // 인증서는 TLSConfig에서 가져옴
+	} else {
+		err = srv.Serve(lis)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// listen은 addr에서 TCP 연결을 받는 리스너를 생성하고, 설정에 따라 PROXY protocol 헤더를 해석하도록 감쌉니다.
+func listen(addr string, cfg proxyproto.Config) (net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := proxyproto.NewListener(lis, cfg)
+	if err != nil {
+		_ = lis.Close()
+		return nil, err
+	}
+	return wrapped, nil
+}
+
+// gracefulStop은 처리 중인 RPC가 끝날 때까지 기다리고, ctx가 만료되면 남은 연결을 강제로 닫습니다.
+func gracefulStop(ctx context.Context, srv *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		srv.Stop()
+		<-stopped
+		return fmt.Errorf("forced stop: %w", ctx.Err())
+	}
+}
+
+// waitGroup은 wg가 끝나거나 ctx가 만료될 때까지 기다립니다.
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/aquaheyday/go-auth-service/internal/usecase"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// AdminServer는 운영자용 AdminService 구현체입니다.
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	outboxUC usecase.OutboxUseCase
+	log      *zap.Logger
+}
+
+func RegisterAdminServer(gs *grpc.Server, srv *AdminServer) {
+	pb.RegisterAdminServiceServer(gs, srv)
+}
+
+func NewAdminServer(logger *zap.Logger, outboxUC usecase.OutboxUseCase) *AdminServer {
+	return &AdminServer{
+		log:      logger,
+		outboxUC: outboxUC,
+	}
+}
+
+func (s *AdminServer) GetOutboxStats(ctx context.Context, _ *pb.GetOutboxStatsReq) (*pb.GetOutboxStatsRes, error) {
+	stats, err := s.outboxUC.Stats(ctx)
+	if err != nil {
+		return nil, s.handleError(ctx, "GetOutboxStats", err)
+	}
+	return &pb.GetOutboxStatsRes{
+		Ready:        stats.Ready,
+		Pending:      stats.Pending,
+		Retrying:     stats.Retrying,
+		DeadLettered: stats.DeadLettered,
+	}, nil
+}
+
+func (s *AdminServer) RedriveOutbox(ctx context.Context, req *pb.RedriveOutboxReq) (*pb.RedriveOutboxRes, error) {
+	n, expired, err := s.outboxUC.Redrive(ctx, int(req.Limit))
+	if err != nil {
+		return nil, s.handleError(ctx, "RedriveOutbox", err)
+	}
+	logger.Ctx(ctx, s.log).Info("outbox redriven", zap.Int("count", n), zap.Int("expired", expired))
+	return &pb.RedriveOutboxRes{Redriven: int32(n), Expired: int32(expired)}, nil
+}
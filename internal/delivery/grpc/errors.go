@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/aquaheyday/go-auth-service/internal/usecase"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain은 ErrorInfo.Domain에 사용하는 서비스 식별자입니다.
+const errorDomain = "auth-service"
+
+// errorMapping은 도메인 에러 하나에 대응하는 gRPC 상태입니다.
+type errorMapping struct {
+	target  error
+	code    codes.Code
+	reason  string // 클라이언트가 분기에 사용할 수 있는 기계 판독용 사유 (ErrorInfo.Reason)
+	message string // 클라이언트에 노출되는 메시지
+}
+
+// errorMappings는 도메인 에러와 gRPC 상태 코드의 대응표입니다. 위에서부터 순서대로 검사합니다.
+var errorMappings = []errorMapping{
+	{domain.ErrInvalidCredentials, codes.Unauthenticated, "INVALID_CREDENTIALS", "invalid credentials"},
+	{domain.ErrInvalidClient, codes.Unauthenticated, "INVALID_CLIENT", "invalid client credentials"},
+	{domain.ErrInvalidToken, codes.Unauthenticated, "INVALID_TOKEN", "invalid or expired token"},
+	{domain.ErrSessionExpired, codes.Unauthenticated, "SESSION_EXPIRED", "session expired, please log in again"},
+	{domain.ErrInvalidCode, codes.InvalidArgument, "INVALID_CODE", "invalid or expired verification code"},
+	{domain.ErrInvalidInput, codes.InvalidArgument, "INVALID_ARGUMENT", "invalid argument"},
+	{domain.ErrAlreadyExists, codes.AlreadyExists, "ALREADY_EXISTS", "resource already exists"},
+	{domain.ErrNotFound, codes.NotFound, "NOT_FOUND", "resource not found"},
+	{domain.ErrLocked, codes.PermissionDenied, "ACCOUNT_LOCKED", "account is locked"},
+	{domain.ErrRateLimited, codes.ResourceExhausted, "RATE_LIMITED", "too many requests"},
+	{domain.ErrSessionLimit, codes.FailedPrecondition, "SESSION_LIMIT", "too many active sessions, log out of another device first"},
+	{domain.ErrFeatureDisabled, codes.FailedPrecondition, "FEATURE_DISABLED", "this feature is currently disabled"},
+	{usecase.ErrSMSNotConfigured, codes.Unimplemented, "SMS_UNAVAILABLE", "phone verification is not available"},
+	{context.Canceled, codes.Canceled, "CANCELED", "request canceled"},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED", "request deadline exceeded"},
+}
+
+// toStatus는 유스케이스 에러를 gRPC 상태로 변환합니다.
+// 이미 gRPC 상태인 에러는 그대로 사용하고, 알 수 없는 에러는 내부 메시지를 숨긴 채 Internal로 변환합니다.
+// 두 번째 반환값은 알 수 없는(서버 측) 에러인지 여부입니다.
+func toStatus(err error) (*status.Status, bool) {
+	if st, ok := status.FromError(err); ok {
+		return st, false
+	}
+
+	for _, m := range errorMappings {
+		if errors.Is(err, m.target) {
+			return withErrorInfo(status.New(m.code, m.message), m.reason), false
+		}
+	}
+	return withErrorInfo(status.New(codes.Internal, "internal error"), "INTERNAL"), true
+}
+
+func withErrorInfo(st *status.Status, reason string) *status.Status {
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// handleError는 핸들러에서 발생한 에러를 기록하고 클라이언트에 반환할 gRPC 에러로 변환합니다.
+// 서버 측 에러는 Error 레벨, 클라이언트 에러는 Info 레벨로 기록하며 에러 메시지의 개인정보는 가립니다.
+func handleError(ctx context.Context, log *zap.Logger, method string, err error) error {
+	log = logger.Ctx(ctx, log)
+	st, internal := toStatus(err)
+	if internal {
+		log.Error(method+" failed", logger.RedactedError(err))
+	} else {
+		log.Info(method+" rejected", zap.String("code", st.Code().String()), logger.RedactedError(err))
+	}
+	return st.Err()
+}
+
+func (s *GRPCServer) handleError(ctx context.Context, method string, err error) error {
+	return handleError(ctx, s.log, method, err)
+}
+
+func (s *AdminServer) handleError(ctx context.Context, method string, err error) error {
+	return handleError(ctx, s.log, method, err)
+}
@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantCode     codes.Code
+		wantReason   string
+		wantInternal bool
+	}{
+		{"locked account", domain.ErrLocked, codes.PermissionDenied, "ACCOUNT_LOCKED", false},
+		{"wrapped locked account", fmt.Errorf("login: %w", domain.ErrLocked), codes.PermissionDenied, "ACCOUNT_LOCKED", false},
+		{"invalid credentials", domain.ErrInvalidCredentials, codes.Unauthenticated, "INVALID_CREDENTIALS", false},
+		{"session limit", domain.ErrSessionLimit, codes.FailedPrecondition, "SESSION_LIMIT", false},
+		{"deadline", fmt.Errorf("redis: %w", context.DeadlineExceeded), codes.DeadlineExceeded, "DEADLINE_EXCEEDED", false},
+		{"unknown error", errors.New("connection refused"), codes.Internal, "INTERNAL", true},
+		{"grpc status passes through", status.Error(codes.Unavailable, "down"), codes.Unavailable, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, internal := toStatus(tt.err)
+			if st.Code() != tt.wantCode || internal != tt.wantInternal {
+				t.Fatalf("toStatus = %v (internal %v), want %v (internal %v)", st.Code(), internal, tt.wantCode, tt.wantInternal)
+			}
+			var reason string
+			for _, d := range st.Details() {
+				if info, ok := d.(*errdetails.ErrorInfo); ok {
+					reason = info.GetReason()
+				}
+			}
+			if reason != tt.wantReason {
+				t.Fatalf("reason = %q, want %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// internal/delivery/grpc/middleware/access_log.go
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AccessLogInterceptor는 RPC 한 건당 하나의 접근 로그를 남기는 인터셉터입니다.
+// 요청의 이메일과 전화번호, 에러 메시지에 포함된 개인정보는 가려서 기록합니다.
+func AccessLogInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("peer", peerAddress(ctx)),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("code", code.String()),
+		}
+		if msg, ok := req.(proto.Message); ok {
+			if email := stringField(msg, "email"); email != "" {
+				fields = append(fields, logger.Email("email", email))
+			}
+			if phone := stringField(msg, "phone_number"); phone != "" {
+				fields = append(fields, logger.Phone("phone_number", phone))
+			}
+		}
+		if msg, ok := resp.(proto.Message); ok {
+			if userID := stringField(msg, "user_id"); userID != "" {
+				fields = append(fields, zap.String("user_id", userID))
+			}
+		}
+		if err != nil {
+			fields = append(fields, logger.RedactedError(err))
+		}
+
+		// 요청 ID는 RequestIDInterceptor가 컨텍스트에 저장한 값을 사용
+		logger.Ctx(ctx, log).Check(accessLogLevel(code), "grpc request").Write(fields...)
+		return resp, err
+	}
+}
+
+// StreamAccessLogInterceptor는 AccessLogInterceptor의 스트림 버전으로, 스트림 하나당 하나의 접근 로그를 남깁니다.
+func StreamAccessLogInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		ctx := ss.Context()
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("peer", peerAddress(ctx)),
+			zap.Duration("duration", time.Since(start)),
+			zap.String("code", code.String()),
+			zap.Bool("client_stream", info.IsClientStream),
+			zap.Bool("server_stream", info.IsServerStream),
+		}
+		if err != nil {
+			fields = append(fields, logger.RedactedError(err))
+		}
+
+		logger.Ctx(ctx, log).Check(accessLogLevel(code), "grpc stream").Write(fields...)
+		return err
+	}
+}
+
+// accessLogLevel은 상태 코드에 따라 로그 레벨을 결정합니다 (서버 측 오류만 Error).
+func accessLogLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK:
+		return zapcore.InfoLevel
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.WarnLevel
+	}
+}
+
+// stringField는 메시지에 name 문자열 필드가 있으면 그 값을 반환합니다.
+func stringField(msg proto.Message, name protoreflect.Name) string {
+	if msg == nil {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return ""
+	}
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind {
+		return ""
+	}
+	return m.Get(fd).String()
+}
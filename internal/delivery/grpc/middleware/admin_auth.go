@@ -0,0 +1,74 @@
+// internal/delivery/grpc/middleware/admin_auth.go
+
+package middleware
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminAuth는 운영자용 서비스의 RPC를 mTLS로 검증된 허용 클라이언트에게만 허용합니다.
+// 허용 목록이 비어 있으면 해당 서비스의 모든 요청을 거부합니다.
+type AdminAuth struct {
+	prefix  string   // "/<서비스 전체 이름>/"
+	allowed []string // 허용할 인증서 신원 (URI SAN, DNS SAN 또는 CN)
+	log     *zap.Logger
+}
+
+// NewAdminAuth는 service(예: "auth.AdminService")의 RPC를 allowed 신원에게만 허용하는 AdminAuth를 생성합니다.
+func NewAdminAuth(service string, allowed []string, log *zap.Logger) *AdminAuth {
+	return &AdminAuth{prefix: "/" + service + "/", allowed: allowed, log: log}
+}
+
+// authorize는 method가 보호 대상이면 요청한 클라이언트의 인증서 신원을 확인합니다.
+func (a *AdminAuth) authorize(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, a.prefix) {
+		return nil
+	}
+	id, ok := ClientIdentityFromContext(ctx)
+	if !ok {
+		logger.Ctx(ctx, a.log).Warn("admin rpc rejected: no verified client certificate", zap.String("method", method))
+		return status.Error(codes.Unauthenticated, "verified client certificate required")
+	}
+	if !a.allows(id) {
+		logger.Ctx(ctx, a.log).Warn("admin rpc rejected: client not allowed",
+			zap.String("method", method), zap.String("client", id.Name()), zap.String("fingerprint", id.Fingerprint))
+		return status.Error(codes.PermissionDenied, "client is not allowed to call admin rpcs")
+	}
+	return nil
+}
+
+// allows는 인증서의 URI SAN, DNS SAN, CN 중 하나라도 허용 목록에 있는지 확인합니다.
+func (a *AdminAuth) allows(id *ClientIdentity) bool {
+	names := append(append([]string{id.CommonName}, id.DNSNames...), id.URIs...)
+	return slices.ContainsFunc(names, func(name string) bool {
+		return name != "" && slices.Contains(a.allowed, name)
+	})
+}
+
+// Interceptor는 AdminAuth를 적용하는 단항 인터셉터입니다.
+func (a *AdminAuth) Interceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor는 Interceptor의 스트림 버전입니다.
+func (a *AdminAuth) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
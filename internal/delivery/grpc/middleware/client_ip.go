@@ -0,0 +1,128 @@
+// internal/delivery/grpc/middleware/client_ip.go
+
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/aquaheyday/go-auth-service/internal/infra/proxyproto"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIPResolver는 연결 주소와 프록시 헤더로 실제 클라이언트 IP를 확인합니다.
+// X-Forwarded-For, X-Real-IP는 신뢰하는 프록시에서 온 연결일 때만 사용하므로 클라이언트가 위조할 수 없습니다.
+type ClientIPResolver struct {
+	trusted []netip.Prefix
+}
+
+// NewClientIPResolver는 trusted 대역의 프록시만 신뢰하는 ClientIPResolver를 생성합니다.
+// trusted가 비어 있으면 항상 연결 주소를 사용합니다.
+func NewClientIPResolver(trusted []netip.Prefix) *ClientIPResolver {
+	return &ClientIPResolver{trusted: trusted}
+}
+
+// Resolve는 클라이언트 IP를 반환합니다.
+// X-Forwarded-For는 각 프록시가 오른쪽에 주소를 덧붙이므로 오른쪽부터 읽으며 신뢰하는 프록시를 건너뛰고,
+// 처음 만나는 신뢰하지 않는 주소를 클라이언트로 봅니다. 그보다 왼쪽 값은 클라이언트가 보낸 값일 수 있어 무시합니다.
+func (r *ClientIPResolver) Resolve(ctx context.Context) string {
+	client, ok := peerIP(ctx)
+	if !ok {
+		return "unknown"
+	}
+	if !proxyproto.Contains(r.trusted, client) {
+		return client.String()
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		hops := strings.Split(strings.Join(values, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop, ok := parseHop(hops[i])
+			if !ok {
+				break // 형식이 잘못된 값부터는 신뢰할 수 없으므로 마지막으로 확인한 프록시 주소 사용
+			}
+			client = hop
+			if !proxyproto.Contains(r.trusted, hop) {
+				break
+			}
+		}
+		return client.String()
+	}
+
+	if values := md.Get("x-real-ip"); len(values) > 0 {
+		if ip, ok := parseHop(values[0]); ok {
+			return ip.String()
+		}
+	}
+	return client.String()
+}
+
+// parseHop은 X-Forwarded-For 항목 하나를 해석합니다 ("203.0.113.7", "203.0.113.7:1234", "[2001:db8::1]:1234").
+func parseHop(s string) (netip.Addr, bool) {
+	s = strings.TrimSpace(s)
+	if ip, err := netip.ParseAddr(s); err == nil {
+		return ip.Unmap(), true
+	}
+	if ap, err := netip.ParseAddrPort(s); err == nil {
+		return ap.Addr().Unmap(), true
+	}
+	return netip.Addr{}, false
+}
+
+// peerIP는 연결 주소의 IP를 반환합니다 (PROXY protocol을 사용하면 프록시가 전달한 원래 주소).
+func peerIP(ctx context.Context) (netip.Addr, bool) {
+	pr, ok := peer.FromContext(ctx)
+	if !ok || pr.Addr == nil {
+		return netip.Addr{}, false
+	}
+	host := pr.Addr.String()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+// peerAddress는 연결 주소의 IP를 문자열로 반환합니다 (없으면 "unknown").
+func peerAddress(ctx context.Context) string {
+	if ip, ok := peerIP(ctx); ok {
+		return ip.String()
+	}
+	return "unknown"
+}
+
+// extractClientIP는 ClientIPInterceptor가 확인한 클라이언트 IP를 반환합니다.
+// 인터셉터를 거치지 않은 경우 프록시 헤더는 신뢰하지 않고 연결 주소를 사용합니다.
+func extractClientIP(ctx context.Context) string {
+	if ip := logger.ClientIPFromContext(ctx); ip != "" {
+		return ip
+	}
+	return peerAddress(ctx)
+}
+
+// ClientIPInterceptor는 클라이언트 IP를 확인해 컨텍스트에 담는 인터셉터입니다.
+// 이후 미들웨어(접근 로그, 속도 제한)와 핸들러 로그, 감사 기록이 같은 값을 사용합니다.
+func ClientIPInterceptor(resolver *ClientIPResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(logger.WithClientIP(ctx, resolver.Resolve(ctx)), req)
+	}
+}
+
+// StreamClientIPInterceptor는 ClientIPInterceptor의 스트림 버전입니다.
+func StreamClientIPInterceptor(resolver *ClientIPResolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = logger.WithClientIP(ss.Context(), resolver.Resolve(ss.Context()))
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIPResolverResolve(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("fd00::/8")}
+	tests := []struct {
+		name string
+		peer string
+		md   metadata.MD
+		want string
+	}{
+		{"no headers", "203.0.113.9", nil, "203.0.113.9"},
+		{"untrusted peer ignores xff", "203.0.113.9", metadata.Pairs("x-forwarded-for", "198.51.100.1"), "203.0.113.9"},
+		{"untrusted peer ignores x-real-ip", "203.0.113.9", metadata.Pairs("x-real-ip", "198.51.100.1"), "203.0.113.9"},
+		{"trusted peer uses xff", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1"), "198.51.100.1"},
+		{"skips trusted hops from the right", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1, 10.0.0.5, 10.0.0.6"), "198.51.100.1"},
+		{"ignores values left of the client", "10.0.0.7", metadata.Pairs("x-forwarded-for", "1.2.3.4, 198.51.100.1, 10.0.0.5"), "198.51.100.1"},
+		{"stops at a malformed hop", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1, not-an-ip, 10.0.0.5"), "10.0.0.5"},
+		{"malformed last hop keeps the peer", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1, bogus"), "10.0.0.7"},
+		{"all hops trusted", "10.0.0.7", metadata.Pairs("x-forwarded-for", "10.0.0.4, 10.0.0.5"), "10.0.0.4"},
+		{"multiple xff headers", "10.0.0.7", metadata.Pairs("x-forwarded-for", "1.2.3.4, 198.51.100.1", "x-forwarded-for", "10.0.0.5"), "198.51.100.1"},
+		{"hop with port", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1:4312"), "198.51.100.1"},
+		{"ipv6 hop with port", "fd00::1", metadata.Pairs("x-forwarded-for", "[2001:db8::1]:4312"), "2001:db8::1"},
+		{"ipv4-mapped trusted peer", "::ffff:10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1"), "198.51.100.1"},
+		{"trusted peer uses x-real-ip", "10.0.0.7", metadata.Pairs("x-real-ip", "198.51.100.1"), "198.51.100.1"},
+		{"malformed x-real-ip", "10.0.0.7", metadata.Pairs("x-real-ip", "bogus"), "10.0.0.7"},
+		{"xff wins over x-real-ip", "10.0.0.7", metadata.Pairs("x-forwarded-for", "198.51.100.1", "x-real-ip", "198.51.100.2"), "198.51.100.1"},
+	}
+	r := NewClientIPResolver(trusted)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := net.ParseIP(tt.peer)
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: ip, Port: 50000}})
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+			if got := r.Resolve(ctx); got != tt.want {
+				t.Fatalf("Resolve = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIPResolverWithoutTrustedProxies(t *testing.T) {
+	r := NewClientIPResolver(nil)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 1}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", "198.51.100.1"))
+	if got := r.Resolve(ctx); got != "10.0.0.7" {
+		t.Fatalf("Resolve = %q, want the peer address", got)
+	}
+	if got := r.Resolve(context.Background()); got != "unknown" {
+		t.Fatalf("Resolve without peer = %q, want unknown", got)
+	}
+}
@@ -0,0 +1,62 @@
+// internal/delivery/grpc/middleware/identity.go
+
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIdentity는 mTLS로 검증된 클라이언트 인증서의 신원 정보입니다.
+type ClientIdentity struct {
+	CommonName  string   // Subject CN
+	DNSNames    []string // SAN DNS 이름
+	URIs        []string // SAN URI (예: spiffe://cluster/ns/default/sa/api)
+	Fingerprint string   // 인증서 DER의 SHA-256 (hex)
+}
+
+// Name은 정책 판단 및 로그에 사용할 대표 이름을 반환합니다 (URI SAN > DNS SAN > CN 순).
+func (id *ClientIdentity) Name() string {
+	switch {
+	case len(id.URIs) > 0:
+		return id.URIs[0]
+	case len(id.DNSNames) > 0:
+		return id.DNSNames[0]
+	default:
+		return id.CommonName
+	}
+}
+
+// ClientIdentityFromContext는 요청을 보낸 클라이언트의 검증된 인증서 신원을 반환합니다.
+// TLS가 아니거나 클라이언트가 검증된 인증서를 제시하지 않았으면 false를 반환합니다.
+func ClientIdentityFromContext(ctx context.Context) (*ClientIdentity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return nil, false
+	}
+	// VerifiedChains는 서버가 CA로 검증에 성공한 경우에만 채워짐
+	chains := tlsInfo.State.VerifiedChains
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return nil, false
+	}
+
+	leaf := chains[0][0]
+	sum := sha256.Sum256(leaf.Raw)
+	id := &ClientIdentity{
+		CommonName:  leaf.Subject.CommonName,
+		DNSNames:    leaf.DNSNames,
+		Fingerprint: hex.EncodeToString(sum[:]),
+	}
+	for _, u := range leaf.URIs {
+		id.URIs = append(id.URIs, u.String())
+	}
+	return id, true
+}
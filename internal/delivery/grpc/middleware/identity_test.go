@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIdentityFromContext(t *testing.T) {
+	spiffe, _ := url.Parse("spiffe://cluster/ns/ops/sa/admin")
+	cert := &x509.Certificate{
+		Raw:      []byte("der bytes"),
+		Subject:  pkix.Name{CommonName: "ops-cli"},
+		DNSNames: []string{"ops.internal"},
+		URIs:     []*url.URL{spiffe},
+	}
+
+	id, ok := ClientIdentityFromContext(withClientCert(cert))
+	if !ok {
+		t.Fatal("verified client certificate not found")
+	}
+	sum := sha256.Sum256(cert.Raw)
+	if id.CommonName != "ops-cli" || !slices.Equal(id.DNSNames, []string{"ops.internal"}) ||
+		!slices.Equal(id.URIs, []string{"spiffe://cluster/ns/ops/sa/admin"}) || id.Fingerprint != hex.EncodeToString(sum[:]) {
+		t.Fatalf("identity = %+v", id)
+	}
+
+	// 검증되지 않은 인증서나 평문 연결은 신원 없음
+	unverified := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}}
+	for name, ctx := range map[string]context.Context{
+		"no peer":          context.Background(),
+		"plaintext":        peer.NewContext(context.Background(), &peer.Peer{}),
+		"unverified chain": peer.NewContext(context.Background(), &peer.Peer{AuthInfo: unverified}),
+		"empty chain":      peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{}}}}}),
+	} {
+		if id, ok := ClientIdentityFromContext(ctx); ok {
+			t.Fatalf("%s: got identity %+v", name, id)
+		}
+	}
+}
+
+func TestClientIdentityName(t *testing.T) {
+	tests := []struct {
+		name string
+		id   ClientIdentity
+		want string
+	}{
+		{"uri san first", ClientIdentity{CommonName: "cn", DNSNames: []string{"dns"}, URIs: []string{"spiffe://a"}}, "spiffe://a"},
+		{"dns san before cn", ClientIdentity{CommonName: "cn", DNSNames: []string{"dns"}}, "dns"},
+		{"common name", ClientIdentity{CommonName: "cn"}, "cn"},
+		{"empty", ClientIdentity{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.id.Name(); got != tt.want {
+				t.Fatalf("Name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,14 +4,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
 
 var (
-	grpcRequestsTotal = promauto.NewCounterVec(
+	grpcRequestsTotal = metrics.Factory.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "grpc_server_requests_total",
 			Help: "Total number of gRPC requests",
@@ -19,21 +19,34 @@ var (
 		[]string{"method", "status"},
 	)
 
-	grpcRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "grpc_server_request_duration_seconds",
-			Help:    "Duration of gRPC requests in seconds",
-			Buckets: prometheus.DefBuckets,
-		},
-		[]string{"method"},
+	grpcRequestDuration = metrics.NewLatencyHistogram(
+		"grpc_server_request_duration_seconds",
+		"Duration of gRPC requests in seconds",
+		"method",
 	)
 
-	activeRequests = promauto.NewGauge(
+	activeRequests = metrics.Factory.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "grpc_server_active_requests",
 			Help: "Number of active gRPC requests",
 		},
 	)
+
+	grpcMsgReceivedTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_msg_received_total",
+			Help: "Total number of stream messages received from clients",
+		},
+		[]string{"method"},
+	)
+
+	grpcMsgSentTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_server_msg_sent_total",
+			Help: "Total number of stream messages sent to clients",
+		},
+		[]string{"method"},
+	)
 )
 
 // MetricsInterceptor returns a gRPC interceptor that collects metrics
@@ -46,20 +59,66 @@ func MetricsInterceptor() grpc.UnaryServerInterceptor {
 		resp, err := handler(ctx, req)
 		duration := time.Since(startTime).Seconds()
 
-		// 메서드와 상태 코드 정보 추출
-		statusCode := "success"
-		if err != nil {
-			if st, ok := status.FromError(err); ok {
-				statusCode = st.Code().String()
-			} else {
-				statusCode = "error"
-			}
-		}
-
 		// 메트릭 기록
-		grpcRequestsTotal.WithLabelValues(info.FullMethod, statusCode).Inc()
-		grpcRequestDuration.WithLabelValues(info.FullMethod).Observe(duration)
+		grpcRequestsTotal.WithLabelValues(info.FullMethod, statusLabel(err)).Inc()
+		grpcRequestDuration.Observe(ctx, duration, info.FullMethod)
 
 		return resp, err
 	}
 }
+
+// StreamMetricsInterceptor는 MetricsInterceptor의 스트림 버전입니다.
+// 스트림 전체를 요청 하나로 집계하고, 주고받은 메시지 수를 따로 기록합니다.
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		activeRequests.Inc()
+		defer activeRequests.Dec()
+
+		startTime := time.Now()
+		err := handler(srv, &countingStream{
+			ServerStream: ss,
+			received:     grpcMsgReceivedTotal.WithLabelValues(info.FullMethod),
+			sent:         grpcMsgSentTotal.WithLabelValues(info.FullMethod),
+		})
+		duration := time.Since(startTime).Seconds()
+
+		grpcRequestsTotal.WithLabelValues(info.FullMethod, statusLabel(err)).Inc()
+		grpcRequestDuration.Observe(ss.Context(), duration, info.FullMethod)
+
+		return err
+	}
+}
+
+// countingStream은 스트림에서 주고받은 메시지 수를 기록합니다.
+type countingStream struct {
+	grpc.ServerStream
+	received prometheus.Counter
+	sent     prometheus.Counter
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+	return err
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err
+}
+
+// statusLabel은 메트릭에 기록할 상태 라벨을 반환합니다.
+func statusLabel(err error) string {
+	if err == nil {
+		return "success"
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code().String()
+	}
+	return "error"
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// messageStream은 pending개의 메시지를 보낸 뒤 io.EOF를 반환하고, 보낸 메시지 수를 세는 스트림입니다.
+type messageStream struct {
+	fakeServerStream
+	pending int
+	sendErr error
+	sent    int
+}
+
+func (s *messageStream) RecvMsg(interface{}) error {
+	if s.pending == 0 {
+		return io.EOF
+	}
+	s.pending--
+	return nil
+}
+
+func (s *messageStream) SendMsg(interface{}) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.sent++
+	return nil
+}
+
+func TestStreamMetricsInterceptor(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		received   int
+		sendErr    error
+		handlerErr error
+		wantStatus string
+		wantSent   float64
+	}{
+		{"success", "/auth.Test/Echo", 3, nil, nil, "success", 3},
+		{"handler error", "/auth.Test/EchoFails", 2, nil, status.Error(codes.PermissionDenied, "denied"), "PermissionDenied", 2},
+		// 전송 실패한 메시지는 세지 않음
+		{"send failure", "/auth.Test/EchoSendFails", 1, errors.New("broken pipe"), nil, "success", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := &messageStream{fakeServerStream: fakeServerStream{ctx: context.Background()}, pending: tt.received, sendErr: tt.sendErr}
+			var activeDuring float64
+			handler := func(_ interface{}, stream grpc.ServerStream) error {
+				activeDuring = testutil.ToFloat64(activeRequests)
+				// 클라이언트 메시지를 끝까지 받고 받은 만큼 응답
+				for {
+					if err := stream.RecvMsg(nil); err != nil {
+						break
+					}
+					_ = stream.SendMsg(nil)
+				}
+				return tt.handlerErr
+			}
+
+			activeBefore := testutil.ToFloat64(activeRequests)
+			requestsBefore := testutil.ToFloat64(grpcRequestsTotal.WithLabelValues(tt.method, tt.wantStatus))
+			err := StreamMetricsInterceptor()(nil, ss, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+			if !errors.Is(err, tt.handlerErr) {
+				t.Fatalf("err = %v, want the handler's error", err)
+			}
+
+			if got := testutil.ToFloat64(grpcMsgReceivedTotal.WithLabelValues(tt.method)); got != float64(tt.received) {
+				t.Fatalf("received = %v, want %d", got, tt.received)
+			}
+			if got := testutil.ToFloat64(grpcMsgSentTotal.WithLabelValues(tt.method)); got != tt.wantSent {
+				t.Fatalf("sent = %v, want %v", got, tt.wantSent)
+			}
+			if got := testutil.ToFloat64(grpcRequestsTotal.WithLabelValues(tt.method, tt.wantStatus)) - requestsBefore; got != 1 {
+				t.Fatalf("requests{status=%q} += %v, want 1 (stream counted once)", tt.wantStatus, got)
+			}
+			if activeDuring != activeBefore+1 || testutil.ToFloat64(activeRequests) != activeBefore {
+				t.Fatalf("active requests during = %v, after = %v, want %v then %v", activeDuring, testutil.ToFloat64(activeRequests), activeBefore+1, activeBefore)
+			}
+		})
+	}
+}
+
+func TestStatusLabel(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "success"},
+		{status.Error(codes.InvalidArgument, "bad"), "InvalidArgument"},
+		{errors.New("plain"), "error"},
+	}
+	for _, tt := range tests {
+		if got := statusLabel(tt.err); got != tt.want {
+			t.Fatalf("statusLabel(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
@@ -4,94 +4,170 @@ package middleware
 
 import (
 	"context"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/peer"
-	"net"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
-	"golang.org/x/time/rate"
+	"github.com/aquaheyday/go-auth-service/internal/infra/ratelimit"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/aquaheyday/go-auth-service/pkg/token"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
-type limiterClient struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
-}
+// RetryAfterTrailer는 제한된 요청에 다시 시도할 수 있을 때까지의 초를 담는 트레일러 키입니다.
+// HTTP 게이트웨이에서는 Retry-After 헤더로 전달됩니다.
+const RetryAfterTrailer = "retry-after"
 
+var rateLimitedTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Name: "grpc_server_rate_limited_total",
+	Help: "Number of requests rejected by the rate limiter, by method and limited dimension (ip, target, user)",
+}, []string{"method", "dimension"})
+
+// RateLimiter는 메서드별 정책에 따라 클라이언트 IP, 대상 이메일/전화번호, 인증된 사용자별로
+// 요청 속도를 제한하는 미들웨어입니다. 버킷 저장 위치(메모리, Redis)는 limiter 구현이 결정합니다.
 type RateLimiter struct {
-	clients map[string]*limiterClient
-	limit   rate.Limit
-	burst   int
-	ttl     time.Duration
+	limiter  ratelimit.Limiter
+	policies atomic.Pointer[ratelimit.PolicyTable]
+	tokens   *token.Issuer // 사용자별 제한에서 토큰을 검증해 사용자를 식별
+	log      *zap.Logger
 }
 
-func NewRateLimiter(rps float64, burst int, ttl time.Duration) *RateLimiter {
-	return &RateLimiter{
-		clients: make(map[string]*limiterClient),
-		limit:   rate.Limit(rps),
-		burst:   burst,
-		ttl:     ttl,
+// NewRateLimiter는 limiter로 policies의 정책을 적용하는 미들웨어를 생성합니다.
+func NewRateLimiter(limiter ratelimit.Limiter, policies *ratelimit.PolicyTable, tokens *token.Issuer, log *zap.Logger) *RateLimiter {
+	l := &RateLimiter{limiter: limiter, tokens: tokens, log: log}
+	l.policies.Store(policies)
+	return l
+}
+
+// SetPolicies는 이후 요청에 적용할 정책을 교체합니다 (설정 재로드).
+// 이미 쌓인 버킷은 유지되며, 다음 요청부터 새 한도로 계산됩니다.
+func (l *RateLimiter) SetPolicies(policies *ratelimit.PolicyTable) {
+	l.policies.Store(policies)
+}
+
+// check는 method의 정책을 대상별로 검사하고, 하나라도 초과하면 재시도 시간과 함께 거부합니다.
+// 식별할 수 없는 대상(요청에 이메일이 없거나 인증 정보가 없는 경우)은 건너뜁니다.
+// limiter가 에러를 반환하면 그 대상은 제한하지 않습니다 (fail-open).
+func (l *RateLimiter) check(ctx context.Context, method string, req proto.Message) (time.Duration, bool) {
+	scope, policy := l.policies.Load().Lookup(method)
+	for _, d := range policy.Dimensions() {
+		id := l.identify(ctx, d.Dimension, req)
+		if id == "" {
+			continue
+		}
+		res, err := l.limiter.Allow(ctx, scope+"|"+d.Dimension+"|"+id, d.Limit)
+		if err != nil {
+			logger.Ctx(ctx, l.log).Warn("rate limit check failed, allowing request",
+				zap.String("dimension", d.Dimension), zap.Error(err))
+			continue
+		}
+		if !res.Allowed {
+			rateLimitedTotal.WithLabelValues(method, d.Dimension).Inc()
+			return res.RetryAfter, false
+		}
 	}
+	return 0, true
 }
 
-func (l *RateLimiter) getClientLimiter(clientIP string) *rate.Limiter {
-	client, exists := l.clients[clientIP]
-	if !exists {
-		client = &limiterClient{
-			limiter:  rate.NewLimiter(l.limit, l.burst),
-			lastSeen: time.Now(),
+// identify는 대상 종류에 맞는 버킷 식별자를 반환합니다.
+func (l *RateLimiter) identify(ctx context.Context, dimension string, req proto.Message) string {
+	switch dimension {
+	case ratelimit.DimensionIP:
+		return extractClientIP(ctx)
+	case ratelimit.DimensionTarget:
+		target := stringField(req, "email")
+		if target == "" {
+			target = stringField(req, "phone_number")
 		}
-		l.clients[clientIP] = client
+		if target == "" {
+			return ""
+		}
+		// 저장소 키에 이메일/전화번호가 그대로 남지 않도록 해시 사용
+		sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(target))))
+		return hex.EncodeToString(sum[:16])
+	case ratelimit.DimensionUser:
+		return l.authenticatedUserID(ctx, req)
 	}
-	client.lastSeen = time.Now()
-	return client.limiter
+	return ""
 }
 
-func (l *RateLimiter) RateLimiterInterceptor() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// 클라이언트 IP 추출
-		clientIP := extractClientIP(ctx)
+// authenticatedUserID는 authorization 헤더의 액세스 토큰, 없으면 요청의 리프레시 토큰에서 사용자 ID를 꺼냅니다.
+// 서명이 유효한 토큰만 사용하므로 다른 사용자의 버킷을 소진시킬 수 없습니다.
+func (l *RateLimiter) authenticatedUserID(ctx context.Context, req proto.Message) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			if raw, ok := strings.CutPrefix(values[0], "Bearer "); ok {
+				if claims, err := l.tokens.ValidateAccessToken(ctx, raw); err == nil {
+					return claims.UserID
+				}
+			}
+		}
+	}
+	if raw := stringField(req, "refresh_token"); raw != "" {
+		if claims, err := l.tokens.ValidateRefreshToken(raw); err == nil {
+			return claims.UserID
+		}
+	}
+	return ""
+}
 
-		limiter := l.getClientLimiter(clientIP)
-		if !limiter.Allow() {
-			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded")
+// rateLimitError는 RetryInfo 상세 정보가 담긴 ResourceExhausted 에러를 만듭니다.
+func rateLimitError(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if retryAfter > 0 {
+		if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+			st = detailed
 		}
+	}
+	return st.Err()
+}
 
-		return handler(ctx, req)
+// retryAfterTrailer는 재시도 시간을 정수 초(올림)로 담은 트레일러를 만듭니다. 알 수 없으면 nil입니다.
+func retryAfterTrailer(retryAfter time.Duration) metadata.MD {
+	if retryAfter <= 0 {
+		return nil
 	}
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	return metadata.Pairs(RetryAfterTrailer, strconv.FormatInt(seconds, 10))
 }
 
-// extractClientIP는 여러 방법으로 클라이언트 IP를 추출합니다
-func extractClientIP(ctx context.Context) string {
-	// 방법 1: peer 정보에서 추출
-	if pr, ok := peer.FromContext(ctx); ok {
-		if pr.Addr != nil {
-			// IP:port 형식에서 IP만 추출
-			addr := pr.Addr.String()
-			if host, _, err := net.SplitHostPort(addr); err == nil {
-				return host
+func (l *RateLimiter) RateLimiterInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		msg, _ := req.(proto.Message)
+		if retryAfter, ok := l.check(ctx, info.FullMethod, msg); !ok {
+			if trailer := retryAfterTrailer(retryAfter); trailer != nil {
+				_ = grpc.SetTrailer(ctx, trailer)
 			}
-			return addr
+			return nil, rateLimitError(retryAfter)
 		}
+
+		return handler(ctx, req)
 	}
+}
 
-	// 방법 2: X-Forwarded-For 헤더에서 추출 (프록시/로드밸런서 환경)
-	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		if values := md.Get("x-forwarded-for"); len(values) > 0 {
-			// 첫 번째 IP가 원래 클라이언트 IP
-			ips := strings.Split(values[0], ",")
-			return strings.TrimSpace(ips[0])
+// StreamRateLimiterInterceptor는 RateLimiterInterceptor의 스트림 버전입니다.
+// 스트림을 여는 시점에 한 번 검사하며, 요청 메시지를 받기 전이므로 target 제한은 적용되지 않습니다.
+func (l *RateLimiter) StreamRateLimiterInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if retryAfter, ok := l.check(ss.Context(), info.FullMethod, nil); !ok {
+			if trailer := retryAfterTrailer(retryAfter); trailer != nil {
+				ss.SetTrailer(trailer)
+			}
+			return rateLimitError(retryAfter)
 		}
 
-		// 방법 3: X-Real-IP 헤더에서 추출
-		if values := md.Get("x-real-ip"); len(values) > 0 {
-			return values[0]
-		}
+		return handler(srv, ss)
 	}
-
-	// 식별 가능한 IP가 없을 경우
-	return "unknown"
 }
@@ -0,0 +1,60 @@
+// internal/delivery/grpc/middleware/recovery.go
+
+package middleware
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var grpcPanicsTotal = metrics.Factory.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "grpc_server_panics_total",
+		Help: "Total number of panics recovered in gRPC handlers",
+	},
+	[]string{"method"},
+)
+
+// RecoveryInterceptor는 핸들러(및 이후 인터셉터)의 panic을 복구해 codes.Internal로 변환하는 인터셉터입니다.
+// panic 값과 스택 트레이스는 로그에만 남기고 클라이언트에는 노출하지 않습니다.
+func RecoveryInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, recovered(ctx, log, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor는 RecoveryInterceptor의 스트림 버전입니다.
+func StreamRecoveryInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), log, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered는 복구한 panic을 기록하고 클라이언트에 반환할 에러를 생성합니다.
+func recovered(ctx context.Context, log *zap.Logger, method string, r interface{}) error {
+	grpcPanicsTotal.WithLabelValues(method).Inc()
+	logger.Ctx(ctx, log).Error("panic recovered in grpc handler",
+		zap.String("method", method),
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+	return status.Error(codes.Internal, "internal error")
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// panicking은 핸들러를 호출하기 전에 panic을 일으키는 미들웨어입니다.
+func panicking(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+	panic("middleware bug")
+}
+
+func TestRecoveryInterceptorRecoversMiddlewarePanics(t *testing.T) {
+	chain := grpc_middleware.ChainUnaryServer(RecoveryInterceptor(zap.NewNop()), panicking)
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+
+	resp, err := chain(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/auth.AuthService/Login"}, handler)
+	if resp != nil || status.Code(err) != codes.Internal {
+		t.Fatalf("chain = %v, %v; want Internal", resp, err)
+	}
+	if st, _ := status.FromError(err); st.Message() != "internal error" {
+		t.Fatalf("panic value leaked to client: %q", st.Message())
+	}
+}
+
+func TestStreamRecoveryInterceptorRecoversMiddlewarePanics(t *testing.T) {
+	chain := grpc_middleware.ChainStreamServer(StreamRecoveryInterceptor(zap.NewNop()),
+		func(interface{}, grpc.ServerStream, *grpc.StreamServerInfo, grpc.StreamHandler) error {
+			panic("middleware bug")
+		})
+	err := chain(nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/auth.AdminService/Watch"},
+		func(interface{}, grpc.ServerStream) error { return nil })
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("chain = %v; want Internal", err)
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,97 @@
+// internal/delivery/grpc/middleware/request_id.go
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader는 요청 ID를 주고받는 메타데이터 키입니다.
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLen은 클라이언트가 보낸 요청 ID로 허용하는 최대 길이입니다.
+const maxRequestIDLen = 128
+
+// RequestIDInterceptor는 클라이언트가 보낸 x-request-id를 사용하거나 새로 생성해
+// 컨텍스트에 저장하고, 응답 헤더와 에러 상세 정보(RequestInfo)에 포함하는 인터셉터입니다.
+// 다른 인터셉터가 요청 ID를 사용할 수 있도록 체인의 가장 바깥쪽에 둡니다.
+func RequestIDInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		ctx = logger.WithRequestID(ctx, id)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, id))
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			err = withRequestInfo(err, id)
+		}
+		return resp, err
+	}
+}
+
+// StreamRequestIDInterceptor는 RequestIDInterceptor의 스트림 버전입니다.
+func StreamRequestIDInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		if id == "" {
+			id = uuid.NewString()
+		}
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = logger.WithRequestID(ss.Context(), id)
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, id))
+
+		if err := handler(srv, wrapped); err != nil {
+			return withRequestInfo(err, id)
+		}
+		return nil
+	}
+}
+
+// incomingRequestID는 수신 메타데이터의 요청 ID를 반환합니다.
+// 로그 오염을 막기 위해 길이를 제한하고 출력 가능한 ASCII 문자만 허용합니다.
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(RequestIDHeader)
+	if len(values) == 0 {
+		return ""
+	}
+	id := values[0]
+	if id == "" || len(id) > maxRequestIDLen {
+		return ""
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return ""
+		}
+	}
+	return id
+}
+
+// withRequestInfo는 gRPC 에러에 RequestInfo 상세 정보를 추가합니다.
+func withRequestInfo(err error, id string) error {
+	st := status.Convert(err)
+	for _, d := range st.Details() {
+		if _, ok := d.(*errdetails.RequestInfo); ok {
+			return err
+		}
+	}
+	detailed, derr := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if derr != nil {
+		return err
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream은 컨텍스트와 송신 헤더만 다루는 grpc.ServerStream입니다.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+func (s *fakeServerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// headerStream은 단항 RPC에서 grpc.SetHeader로 설정한 헤더를 기록합니다.
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// requestIDDetail은 에러 상세 정보의 RequestInfo 요청 ID를 반환합니다.
+func requestIDDetail(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RequestInfo); ok {
+			return info.GetRequestId()
+		}
+	}
+	return ""
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming []string // 클라이언트가 보낸 x-request-id (nil이면 헤더 없음)
+		want     string   // 비어 있으면 새로 생성
+	}{
+		{"propagated", []string{"req-123"}, "req-123"},
+		{"first of several", []string{"req-1", "req-2"}, "req-1"},
+		{"generated when missing", nil, ""},
+		{"generated when empty", []string{""}, ""},
+		{"generated when too long", []string{strings.Repeat("a", maxRequestIDLen+1)}, ""},
+		{"generated for control characters", []string{"req\n{\"level\":\"error\"}"}, ""},
+		{"generated for spaces", []string{"req 123"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.incoming != nil {
+				ctx = metadata.NewIncomingContext(ctx, metadata.MD{RequestIDHeader: tt.incoming})
+			}
+			stream := &headerStream{}
+			ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+			var seen string
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				seen = logger.RequestIDFromContext(ctx)
+				return nil, status.Error(codes.NotFound, "not found")
+			}
+			_, err := RequestIDInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			if tt.want != "" && seen != tt.want {
+				t.Fatalf("request id = %q, want %q", seen, tt.want)
+			}
+			if tt.want == "" {
+				if _, perr := uuid.Parse(seen); perr != nil {
+					t.Fatalf("generated request id %q is not a UUID", seen)
+				}
+			}
+			// 핸들러, 응답 헤더, 에러 상세 정보가 같은 ID를 사용
+			if got := stream.header.Get(RequestIDHeader); len(got) != 1 || got[0] != seen {
+				t.Fatalf("response header = %v, want %q", got, seen)
+			}
+			if got := requestIDDetail(err); got != seen {
+				t.Fatalf("RequestInfo = %q, want %q", got, seen)
+			}
+			if status.Code(err) != codes.NotFound {
+				t.Fatalf("code = %v, want the handler's NotFound", status.Code(err))
+			}
+		})
+	}
+}
+
+func TestRequestIDInterceptorKeepsExistingRequestInfo(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "outer"))
+	ctx = grpc.NewContextWithServerTransportStream(ctx, &headerStream{})
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return nil, withRequestInfo(status.Error(codes.Internal, "internal error"), "inner")
+	}
+	_, err := RequestIDInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	if got := requestIDDetail(err); got != "inner" {
+		t.Fatalf("RequestInfo = %q, want the existing detail kept", got)
+	}
+	if n := len(status.Convert(err).Details()); n != 1 {
+		t.Fatalf("%d details, want 1", n)
+	}
+}
+
+func TestStreamRequestIDInterceptor(t *testing.T) {
+	for _, incoming := range []string{"stream-req", ""} {
+		ctx := context.Background()
+		if incoming != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDHeader, incoming))
+		}
+		ss := &fakeServerStream{ctx: ctx}
+
+		var seen string
+		handler := func(_ interface{}, stream grpc.ServerStream) error {
+			seen = logger.RequestIDFromContext(stream.Context())
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		err := StreamRequestIDInterceptor()(nil, ss, &grpc.StreamServerInfo{}, handler)
+
+		if incoming != "" && seen != incoming {
+			t.Fatalf("request id = %q, want %q", seen, incoming)
+		}
+		if seen == "" {
+			t.Fatal("no request id in the stream context")
+		}
+		if got := ss.header.Get(RequestIDHeader); len(got) != 1 || got[0] != seen {
+			t.Fatalf("response header = %v, want %q", got, seen)
+		}
+		if got := requestIDDetail(err); got != seen {
+			t.Fatalf("RequestInfo = %q, want %q", got, seen)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// internal/delivery/grpc/middleware/validation.go
+
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+)
+
+// rule은 필드 값 하나를 검사하고, 위반 시 설명을 반환합니다 (통과하면 빈 문자열).
+type rule func(v protoreflect.Value) string
+
+// field는 메시지 필드 하나에 적용할 규칙 목록입니다.
+type field struct {
+	name  protoreflect.Name
+	rules []rule
+}
+
+var (
+	e164Pattern      = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	emailCodePattern = regexp.MustCompile(`^[0-9a-f]{6}$`)
+	phoneCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+	clientIDPattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+)
+
+// 공통 필드 규칙
+var (
+	emailField        = field{"email", []rule{required(), maxLen(254), email()}}
+	phoneField        = field{"phone_number", []rule{required(), pattern(e164Pattern, "must be in E.164 format (e.g. +821012345678)")}}
+	newPasswordField  = field{"password", []rule{required(), minBytes(8), maxBytes(72)}} // bcrypt는 72바이트까지만 사용
+	passwordField     = field{"password", []rule{required(), maxBytes(72)}}              // 기존 사용자 로그인은 길이 하한 미적용
+	emailCodeField    = field{"code", []rule{required(), pattern(emailCodePattern, "must be 6 lowercase hex characters")}}
+	phoneCodeField    = field{"code", []rule{required(), pattern(phoneCodePattern, "must be 6 digits")}}
+	refreshTokenField = field{"refresh_token", []rule{required(), maxLen(4096)}}
+	clientIDField     = field{"client_id", []rule{pattern(clientIDPattern, "must be 1-64 lowercase letters, digits, '.', '_' or '-'")}} // 선택 필드
+	clientSecretField = field{"client_secret", []rule{maxBytes(256)}}                                                                   // 선택 필드 (일치 여부는 유스케이스에서 확인)
+)
+
+// validationRules는 proto/auth.proto의 요청 메시지별 검증 규칙입니다 (키: 메시지 전체 이름).
+var validationRules = map[protoreflect.FullName][]field{
+	"auth.SendVerificationReq":      {emailField},
+	"auth.VerifyCodeReq":            {emailField, emailCodeField},
+	"auth.SignUpReq":                {emailField, newPasswordField, emailCodeField},
+	"auth.LoginReq":                 {emailField, passwordField, clientIDField, clientSecretField},
+	"auth.RefreshTokenReq":          {refreshTokenField},
+	"auth.LogoutReq":                {refreshTokenField},
+	"auth.SendPhoneVerificationReq": {phoneField},
+	"auth.VerifyPhoneCodeReq":       {phoneField, phoneCodeField},
+	"auth.SignUpWithPhoneReq":       {phoneField, newPasswordField, phoneCodeField, {"name", []rule{maxLen(100)}}},
+	"auth.LoginWithPhoneReq":        {phoneField, passwordField},
+	"auth.GetOutboxStatsReq":        {},
+	"auth.RedriveOutboxReq":         {{"limit", []rule{intRange(0, 1000)}}},
+}
+
+// 규칙의 메시지/필드 이름 오타는 검증이 조용히 빠지는 것이므로 시작 시점에 실패시킵니다.
+func init() {
+	if err := checkValidationRules(pb.File_auth_proto, validationRules); err != nil {
+		panic(err)
+	}
+}
+
+// checkValidationRules는 rules의 메시지와 필드가 모두 file에 정의되어 있는지 확인합니다.
+func checkValidationRules(file protoreflect.FileDescriptor, rules map[protoreflect.FullName][]field) error {
+	for name, fields := range rules {
+		md := file.Messages().ByName(name.Name())
+		if md == nil || md.FullName() != name {
+			return fmt.Errorf("validation: unknown message %s", name)
+		}
+		for _, f := range fields {
+			if md.Fields().ByName(f.name) == nil {
+				return fmt.Errorf("validation: unknown field %s.%s", name, f.name)
+			}
+		}
+	}
+	return nil
+}
+
+func required() rule {
+	return func(v protoreflect.Value) string {
+		if v.String() == "" {
+			return "is required"
+		}
+		return ""
+	}
+}
+
+// 아래 규칙들은 빈 값은 통과시킵니다 (필수 여부는 required로 검사).
+
+func maxLen(n int) rule {
+	return func(v protoreflect.Value) string {
+		if utf8.RuneCountInString(v.String()) > n {
+			return fmt.Sprintf("must be at most %d characters", n)
+		}
+		return ""
+	}
+}
+
+func minBytes(n int) rule {
+	return func(v protoreflect.Value) string {
+		if s := v.String(); s != "" && len(s) < n {
+			return fmt.Sprintf("must be at least %d bytes", n)
+		}
+		return ""
+	}
+}
+
+func maxBytes(n int) rule {
+	return func(v protoreflect.Value) string {
+		if len(v.String()) > n {
+			return fmt.Sprintf("must be at most %d bytes", n)
+		}
+		return ""
+	}
+}
+
+func pattern(re *regexp.Regexp, description string) rule {
+	return func(v protoreflect.Value) string {
+		if s := v.String(); s != "" && !re.MatchString(s) {
+			return description
+		}
+		return ""
+	}
+}
+
+func email() rule {
+	return func(v protoreflect.Value) string {
+		s := v.String()
+		if s == "" {
+			return ""
+		}
+		// 표시 이름("Name <a@b.c>") 없이 주소만 허용
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s || addr.Name != "" {
+			return "must be a valid email address"
+		}
+		return ""
+	}
+}
+
+func intRange(min, max int64) rule {
+	return func(v protoreflect.Value) string {
+		if n := v.Int(); n < min || n > max {
+			return fmt.Sprintf("must be between %d and %d", min, max)
+		}
+		return ""
+	}
+}
+
+// validate는 메시지에 등록된 규칙을 검사하고 위반 목록을 반환합니다.
+func validate(msg proto.Message) []*errdetails.BadRequest_FieldViolation {
+	m := msg.ProtoReflect()
+	fields, ok := validationRules[m.Descriptor().FullName()]
+	if !ok {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, f := range fields {
+		// 필드 존재 여부는 init의 checkValidationRules에서 보장
+		v := m.Get(m.Descriptor().Fields().ByName(f.name))
+		// 필드당 첫 번째 위반만 보고
+		for _, r := range f.rules {
+			if desc := r(v); desc != "" {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       string(f.name),
+					Description: desc,
+				})
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// validationError는 위반 목록을 BadRequest 상세 정보가 담긴 InvalidArgument 에러로 변환합니다.
+func validationError(violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "invalid request")
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// ValidationInterceptor는 요청 메시지를 검증 규칙에 따라 검사하고,
+// 위반이 있으면 핸들러를 호출하지 않고 codes.InvalidArgument를 반환하는 인터셉터입니다.
+func ValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if violations := validate(msg); len(violations) > 0 {
+				return nil, validationError(violations)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidationInterceptor는 ValidationInterceptor의 스트림 버전입니다.
+// 클라이언트가 보낸 메시지를 받을 때마다 검사하고, 위반이 있으면 RecvMsg가 InvalidArgument를 반환합니다.
+func StreamValidationInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+// validatingStream은 수신 메시지를 검증하는 스트림입니다.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if violations := validate(msg); len(violations) > 0 {
+			return validationError(violations)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+)
+
+// 새 RPC를 추가하면서 규칙을 빠뜨리면 검증 없이 핸들러까지 도달하므로 모든 요청 메시지에 규칙이 있어야 합니다.
+func TestEveryRequestHasValidationRules(t *testing.T) {
+	services := pb.File_auth_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			input := methods.Get(j).Input().FullName()
+			if _, ok := validationRules[input]; !ok {
+				t.Errorf("%s has no validation rules", input)
+			}
+		}
+	}
+}
+
+func TestCheckValidationRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[protoreflect.FullName][]field
+		wantErr string
+	}{
+		{"registered rules", validationRules, ""},
+		{"misspelled message", map[protoreflect.FullName][]field{"auth.LogInReq": {emailField}}, "unknown message auth.LogInReq"},
+		{"other package", map[protoreflect.FullName][]field{"other.LoginReq": {emailField}}, "unknown message other.LoginReq"},
+		{"misspelled field", map[protoreflect.FullName][]field{"auth.LoginReq": {{"emial", []rule{required()}}}}, "unknown field auth.LoginReq.emial"},
+		{"field of another message", map[protoreflect.FullName][]field{"auth.LoginReq": {phoneField}}, "unknown field auth.LoginReq.phone_number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkValidationRules(pb.File_auth_proto, tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	const validPassword = "correct-horse"
+	tests := []struct {
+		name string
+		req  proto.Message
+		want []string // 위반 필드 (비어 있으면 통과)
+	}{
+		{"valid signup", &pb.SignUpReq{Email: "user@example.com", Password: validPassword, Code: "a1b2c3"}, nil},
+		{"missing email", &pb.SendVerificationReq{}, []string{"email"}},
+		{"email with display name", &pb.SendVerificationReq{Email: "User <user@example.com>"}, []string{"email"}},
+		{"malformed email", &pb.SendVerificationReq{Email: "user@"}, []string{"email"}},
+		{"too long email", &pb.SendVerificationReq{Email: strings.Repeat("a", 250) + "@example.com"}, []string{"email"}},
+		{"short password", &pb.SignUpReq{Email: "user@example.com", Password: "short", Code: "a1b2c3"}, []string{"password"}},
+		{"password over bcrypt limit", &pb.SignUpReq{Email: "user@example.com", Password: strings.Repeat("p", 73), Code: "a1b2c3"}, []string{"password"}},
+		{"short login password allowed", &pb.LoginReq{Email: "user@example.com", Password: "short"}, nil},
+		{"uppercase email code", &pb.VerifyCodeReq{Email: "user@example.com", Code: "A1B2C3"}, []string{"code"}},
+		{"short email code", &pb.VerifyCodeReq{Email: "user@example.com", Code: "a1b2"}, []string{"code"}},
+		{"several violations", &pb.SignUpReq{Email: "nope", Password: "", Code: "zzzzzz"}, []string{"email", "password", "code"}},
+		{"valid phone", &pb.VerifyPhoneCodeReq{PhoneNumber: "+821012345678", Code: "123456"}, nil},
+		{"phone without country code", &pb.SendPhoneVerificationReq{PhoneNumber: "01012345678"}, []string{"phone_number"}},
+		{"hex phone code", &pb.VerifyPhoneCodeReq{PhoneNumber: "+821012345678", Code: "a1b2c3"}, []string{"code"}},
+		{"bad client id", &pb.LoginReq{Email: "user@example.com", Password: validPassword, ClientId: "Web App"}, []string{"client_id"}},
+		{"redrive limit out of range", &pb.RedriveOutboxReq{Limit: 1001}, []string{"limit"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return "ok", nil
+			}
+			_, err := ValidationInterceptor()(context.Background(), tt.req, &grpc.UnaryServerInfo{}, handler)
+			if len(tt.want) == 0 {
+				if err != nil || !called {
+					t.Fatalf("valid request rejected: %v", err)
+				}
+				return
+			}
+			if called {
+				t.Fatal("handler called for an invalid request")
+			}
+			st := status.Convert(err)
+			if st.Code() != codes.InvalidArgument {
+				t.Fatalf("code = %v, want InvalidArgument", st.Code())
+			}
+			var got []string
+			for _, d := range st.Details() {
+				if br, ok := d.(*errdetails.BadRequest); ok {
+					for _, v := range br.GetFieldViolations() {
+						got = append(got, v.GetField())
+					}
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
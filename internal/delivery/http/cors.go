@@ -0,0 +1,89 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSConfig는 브라우저 교차 출처 요청 허용 정책입니다.
+type CORSConfig struct {
+	AllowedOrigins   []string // 허용할 Origin 목록 ("*"이면 모든 출처 허용, 자격 증명은 허용하지 않음)
+	AllowedHeaders   []string // 허용할 요청 헤더
+	ExposedHeaders   []string // 브라우저 스크립트에 노출할 응답 헤더
+	AllowCredentials bool     // 쿠키/인증 헤더 포함 요청 허용
+	MaxAge           int      // preflight 응답 캐시 시간 (초)
+}
+
+var (
+	defaultAllowedHeaders = []string{"Authorization", "Content-Type", "X-Request-Id", "Traceparent", "Tracestate"}
+	defaultExposedHeaders = []string{"X-Request-Id", "Retry-After"}
+)
+
+func (c CORSConfig) allowOrigin(origin string) (string, bool) {
+	for _, o := range c.AllowedOrigins {
+		if o == "*" {
+			// 요청 Origin을 그대로 돌려주면 자격 증명 요청까지 모든 출처에 허용되므로 항상 "*"로 응답
+			// (설정 검증에서 allow_credentials와 함께 쓰는 것을 거부함)
+			return "*", true
+		}
+		if strings.EqualFold(o, origin) {
+			return origin, true
+		}
+	}
+	return "", false
+}
+
+// wrap은 next에 CORS 처리를 추가합니다. AllowedOrigins가 비어 있으면 CORS 헤더를 추가하지 않습니다.
+func (c CORSConfig) wrap(next http.Handler) http.Handler {
+	if len(c.AllowedOrigins) == 0 {
+		return next
+	}
+
+	allowedHeaders := c.AllowedHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = defaultAllowedHeaders
+	}
+	exposedHeaders := c.ExposedHeaders
+	if len(exposedHeaders) == 0 {
+		exposedHeaders = defaultExposedHeaders
+	}
+	maxAge := c.MaxAge
+	if maxAge <= 0 {
+		maxAge = 600
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+		allowed, ok := c.allowOrigin(origin)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		h.Set("Access-Control-Allow-Origin", allowed)
+		if c.AllowCredentials && allowed != "*" {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		// preflight 요청
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			h.Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+			h.Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
+			h.Set("Access-Control-Max-Age", strconv.Itoa(maxAge))
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		h.Set("Access-Control-Expose-Headers", strings.Join(exposedHeaders, ", "))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSWildcardNeverReflectsOrigin(t *testing.T) {
+	for _, creds := range []bool{false, true} {
+		c := CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: creds}
+		h := c.wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil)
+		req.Header.Set("Origin", "https://evil.example")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Fatalf("credentials=%v: Allow-Origin = %q, want *", creds, got)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+			t.Fatalf("credentials=%v: Allow-Credentials = %q for wildcard origin", creds, got)
+		}
+	}
+}
+
+func TestCORSExplicitOriginWithCredentials(t *testing.T) {
+	c := CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}
+	h := c.wrap(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	for origin, want := range map[string]string{
+		"https://app.example.com": "https://app.example.com",
+		"https://evil.example":    "",
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/auth/login", nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != want {
+			t.Fatalf("origin %s: Allow-Origin = %q, want %q", origin, got, want)
+		}
+		if wantCreds := want != ""; (rec.Header().Get("Access-Control-Allow-Credentials") == "true") != wantCreds {
+			t.Fatalf("origin %s: Allow-Credentials present = %v", origin, !wantCreds)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+// internal/delivery/http/gateway.go
+// 이 파일은 AuthService의 모든 RPC를 REST/JSON으로 노출하는 HTTP 게이트웨이를 정의합니다.
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxBodyBytes는 요청 본문 최대 크기입니다.
+const maxBodyBytes = 1 << 20
+
+var (
+	// proto/auth.proto의 필드명(snake_case)을 그대로 사용하고, 기본값 필드도 항상 포함
+	marshalOpts   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOpts = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// forwardedHeaders는 gRPC 메타데이터로 전달할 HTTP 헤더입니다.
+var forwardedHeaders = []string{
+	"authorization",
+	"user-agent",
+	"x-request-id",
+	"x-forwarded-for",
+	"x-real-ip",
+	"traceparent",
+	"tracestate",
+}
+
+// Gateway는 HTTP 요청을 AuthService 핸들러 호출로 변환합니다.
+// 별도의 gRPC 연결 없이 같은 프로세스에서 핸들러를 직접 호출하되,
+// gRPC 서버와 동일한 인터셉터 체인(메트릭, 속도 제한 등)을 거치도록 합니다.
+type Gateway struct {
+	srv         pb.AuthServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	log         *zap.Logger
+	mux         *http.ServeMux
+	paths       map[string]bool // 등록된 경로 (405 응답 판단용)
+	handler     http.Handler
+}
+
+// NewGateway 생성자 함수는 핸들러 구현체와 인터셉터 체인을 주입받아 게이트웨이를 생성합니다.
+// interceptor가 nil이면 핸들러를 바로 호출합니다.
+func NewGateway(srv pb.AuthServiceServer, interceptor grpc.UnaryServerInterceptor, cors CORSConfig, log *zap.Logger) *Gateway {
+	g := &Gateway{
+		srv:         srv,
+		interceptor: interceptor,
+		log:         log,
+		mux:         http.NewServeMux(),
+		paths:       make(map[string]bool),
+	}
+
+	handle(g, "POST /v1/verification/email", pb.AuthService_SendVerification_FullMethodName, http.StatusOK, srv.SendVerification)
+	handle(g, "POST /v1/verification/email/verify", pb.AuthService_VerifyCode_FullMethodName, http.StatusOK, srv.VerifyCode)
+	handle(g, "POST /v1/signup", pb.AuthService_SignUp_FullMethodName, http.StatusCreated, srv.SignUp)
+	handle(g, "POST /v1/login", pb.AuthService_Login_FullMethodName, http.StatusOK, srv.Login)
+	handle(g, "POST /v1/token/refresh", pb.AuthService_RefreshToken_FullMethodName, http.StatusOK, srv.RefreshToken)
+	handle(g, "POST /v1/logout", pb.AuthService_Logout_FullMethodName, http.StatusOK, srv.Logout)
+	handle(g, "POST /v1/verification/phone", pb.AuthService_SendPhoneVerification_FullMethodName, http.StatusOK, srv.SendPhoneVerification)
+	handle(g, "POST /v1/verification/phone/verify", pb.AuthService_VerifyPhoneCode_FullMethodName, http.StatusOK, srv.VerifyPhoneCode)
+	handle(g, "POST /v1/signup/phone", pb.AuthService_SignUpWithPhone_FullMethodName, http.StatusCreated, srv.SignUpWithPhone)
+	handle(g, "POST /v1/login/phone", pb.AuthService_LoginWithPhone_FullMethodName, http.StatusOK, srv.LoginWithPhone)
+
+	// 등록된 경로에 다른 메서드로 요청한 경우 405, 그 외는 404
+	g.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if g.paths[r.URL.Path] {
+			w.Header().Set("Allow", http.MethodPost)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_, _ = w.Write([]byte(`{"code":12,"message":"method not allowed","details":[]}`))
+			return
+		}
+		writeError(w, status.Error(codes.NotFound, "route not found"))
+	})
+
+	g.handler = cors.wrap(g.mux)
+	return g
+}
+
+// Handler는 CORS 처리가 포함된 HTTP 핸들러를 반환합니다.
+func (g *Gateway) Handler() http.Handler {
+	return g.handler
+}
+
+// handle은 pattern 경로에 RPC 하나를 등록합니다.
+func handle[Req any, Res proto.Message, ReqPtr interface {
+	*Req
+	proto.Message
+}](g *Gateway, pattern, fullMethod string, successStatus int, call func(context.Context, ReqPtr) (Res, error)) {
+	_, path, _ := strings.Cut(pattern, " ")
+	g.paths[path] = true
+
+	g.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		req := ReqPtr(new(Req))
+		if err := decodeBody(w, r, req); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		ctx, span := startServerSpan(incomingContext(r), r, fullMethod, path)
+		stream := &transportStream{method: fullMethod}
+		ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(ctx, req.(ReqPtr))
+		}
+
+		var (
+			resp interface{}
+			err  error
+		)
+		if g.interceptor != nil {
+			info := &grpc.UnaryServerInfo{Server: g.srv, FullMethod: fullMethod}
+			resp, err = g.interceptor(ctx, req, info, handler)
+		} else {
+			resp, err = handler(ctx, req)
+		}
+		endServerSpan(span, err)
+
+		stream.writeHeaders(w)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		body, err := marshalOpts.Marshal(resp.(proto.Message))
+		if err != nil {
+			g.log.Error("gateway response marshal failed", zap.String("method", fullMethod), zap.Error(err))
+			writeError(w, status.Error(codes.Internal, "internal error"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(successStatus)
+		_, _ = w.Write(body)
+	})
+}
+
+// decodeBody는 JSON 본문을 req에 채웁니다. 본문이 비어 있으면 빈 메시지로 처리합니다.
+func decodeBody(w http.ResponseWriter, r *http.Request, req proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return status.Error(codes.InvalidArgument, "request body too large")
+		}
+		return status.Error(codes.InvalidArgument, "failed to read request body")
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	if err := unmarshalOpts.Unmarshal(body, req); err != nil {
+		return status.Error(codes.InvalidArgument, "invalid JSON body")
+	}
+	return nil
+}
+
+// incomingContext는 HTTP 요청 정보를 gRPC 서버 핸들러가 보는 것과 같은 형태로 ctx에 담습니다.
+func incomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for _, h := range forwardedHeaders {
+		if values := r.Header.Values(h); len(values) > 0 {
+			md.Set(h, values...)
+		}
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), md)
+
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return ctx
+}
+
+// transportStream은 인터셉터/핸들러가 grpc.SetHeader, grpc.SetTrailer로 설정한 메타데이터를 모아
+// HTTP 응답 헤더로 전달합니다.
+type transportStream struct {
+	method string
+
+	mu      sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *transportStream) Method() string { return s.method }
+
+func (s *transportStream) SetHeader(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *transportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *transportStream) SetTrailer(md metadata.MD) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+func (s *transportStream) writeHeaders(w http.ResponseWriter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, md := range []metadata.MD{s.header, s.trailer} {
+		for k, values := range md {
+			if strings.HasPrefix(k, "grpc-") || strings.HasPrefix(k, ":") {
+				continue
+			}
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+}
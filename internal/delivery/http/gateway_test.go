@@ -0,0 +1,217 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pb "github.com/aquaheyday/go-auth-service/pkg/pb/auth"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthServer는 받은 요청을 기록하고 err을 반환하는 AuthService 구현입니다.
+type fakeAuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	err     error
+	refresh *pb.RefreshTokenReq
+}
+
+func (s *fakeAuthServer) SendVerification(context.Context, *pb.SendVerificationReq) (*pb.SendVerificationRes, error) {
+	return &pb.SendVerificationRes{Message: "sent"}, s.err
+}
+
+func (s *fakeAuthServer) SignUp(context.Context, *pb.SignUpReq) (*pb.SignUpRes, error) {
+	return &pb.SignUpRes{UserId: "u1"}, s.err
+}
+
+func (s *fakeAuthServer) Login(context.Context, *pb.LoginReq) (*pb.LoginRes, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &pb.LoginRes{UserId: "u1", AccessToken: "access"}, nil
+}
+
+func (s *fakeAuthServer) RefreshToken(_ context.Context, req *pb.RefreshTokenReq) (*pb.RefreshTokenRes, error) {
+	s.refresh = req
+	return &pb.RefreshTokenRes{AccessToken: "access", RefreshToken: "refresh"}, nil
+}
+
+// serve는 게이트웨이에 요청 하나를 보내고 응답을 기록합니다.
+func serve(t *testing.T, g *Gateway, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	rec := httptest.NewRecorder()
+	g.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeJSON은 응답 본문을 맵으로 읽습니다.
+func decodeJSON(t *testing.T, rec *httptest.ResponseRecorder) map[string]any {
+	t.Helper()
+	var m map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &m); err != nil {
+		t.Fatalf("response is not JSON: %q", rec.Body.String())
+	}
+	return m
+}
+
+func TestGatewayRoutes(t *testing.T) {
+	var called string
+	record := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		called = info.FullMethod
+		return handler(ctx, req)
+	}
+	g := NewGateway(&fakeAuthServer{}, record, CORSConfig{}, zap.NewNop())
+
+	tests := []struct {
+		method, path string
+		wantStatus   int
+		wantMethod   string
+	}{
+		{http.MethodPost, "/v1/verification/email", http.StatusOK, pb.AuthService_SendVerification_FullMethodName},
+		{http.MethodPost, "/v1/signup", http.StatusCreated, pb.AuthService_SignUp_FullMethodName},
+		{http.MethodPost, "/v1/login", http.StatusOK, pb.AuthService_Login_FullMethodName},
+		{http.MethodPost, "/v1/token/refresh", http.StatusOK, pb.AuthService_RefreshToken_FullMethodName},
+		// 구현하지 않은 RPC는 Unimplemented → 501
+		{http.MethodPost, "/v1/login/phone", http.StatusNotImplemented, pb.AuthService_LoginWithPhone_FullMethodName},
+		{http.MethodGet, "/v1/login", http.StatusMethodNotAllowed, ""},
+		{http.MethodPost, "/v1/unknown", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			called = ""
+			rec := serve(t, g, tt.method, tt.path, "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if called != tt.wantMethod {
+				t.Fatalf("interceptor saw %q, want %q", called, tt.wantMethod)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Fatalf("Content-Type = %q", ct)
+			}
+			if tt.wantStatus == http.StatusMethodNotAllowed && rec.Header().Get("Allow") != http.MethodPost {
+				t.Fatalf("Allow = %q, want POST", rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestGatewayErrorStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.FailedPrecondition, http.StatusBadRequest},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.Canceled, 499},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.Internal, http.StatusInternalServerError},
+		{codes.Unknown, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			g := NewGateway(&fakeAuthServer{err: status.Error(tt.code, "failed")}, nil, CORSConfig{}, zap.NewNop())
+			rec := serve(t, g, http.MethodPost, "/v1/login", `{}`)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			// 본문은 google.rpc.Status JSON
+			body := decodeJSON(t, rec)
+			if body["code"] != float64(tt.code) || body["message"] != "failed" {
+				t.Fatalf("body = %v", body)
+			}
+		})
+	}
+}
+
+func TestGatewayRetryAfterFromTrailer(t *testing.T) {
+	limit := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		_ = grpc.SetTrailer(ctx, metadata.Pairs("retry-after", "7", "grpc-status-details-bin", "x"))
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	g := NewGateway(&fakeAuthServer{}, limit, CORSConfig{}, zap.NewNop())
+
+	rec := serve(t, g, http.MethodPost, "/v1/login", `{}`)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "7" {
+		t.Fatalf("Retry-After = %q, want 7", got)
+	}
+	// gRPC 내부 메타데이터는 HTTP 헤더로 노출하지 않음
+	if got := rec.Header().Get("Grpc-Status-Details-Bin"); got != "" {
+		t.Fatalf("grpc-* trailer leaked as header: %q", got)
+	}
+}
+
+func TestGatewayRequestBody(t *testing.T) {
+	srv := &fakeAuthServer{}
+	g := NewGateway(srv, nil, CORSConfig{}, zap.NewNop())
+
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantMessage string
+		wantToken   string
+	}{
+		{"empty body", "", http.StatusOK, "", ""},
+		{"proto field name", `{"refresh_token":"r1"}`, http.StatusOK, "", "r1"},
+		{"json field name", `{"refreshToken":"r2"}`, http.StatusOK, "", "r2"},
+		{"unknown field ignored", `{"refresh_token":"r3","extra":true}`, http.StatusOK, "", "r3"},
+		{"invalid json", `{"refresh_token":`, http.StatusBadRequest, "invalid JSON body", ""},
+		{"wrong type", `{"refresh_token":1}`, http.StatusBadRequest, "invalid JSON body", ""},
+		{"too large", `{"refresh_token":"` + strings.Repeat("a", maxBodyBytes) + `"}`, http.StatusBadRequest, "request body too large", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.refresh = nil
+			rec := serve(t, g, http.MethodPost, "/v1/token/refresh", tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantMessage != "" {
+				if msg := decodeJSON(t, rec)["message"]; msg != tt.wantMessage {
+					t.Fatalf("message = %v, want %q", msg, tt.wantMessage)
+				}
+				if srv.refresh != nil {
+					t.Fatal("handler called for a rejected body")
+				}
+				return
+			}
+			if srv.refresh.GetRefreshToken() != tt.wantToken {
+				t.Fatalf("refresh_token = %q, want %q", srv.refresh.GetRefreshToken(), tt.wantToken)
+			}
+		})
+	}
+}
+
+func TestGatewayResponseUsesProtoFieldNames(t *testing.T) {
+	g := NewGateway(&fakeAuthServer{}, nil, CORSConfig{}, zap.NewNop())
+
+	body := decodeJSON(t, serve(t, g, http.MethodPost, "/v1/login", `{}`))
+	want := map[string]any{"user_id": "u1", "access_token": "access", "refresh_token": ""} // 빈 필드도 포함
+	if len(body) != len(want) {
+		t.Fatalf("body = %v, want %v", body, want)
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Fatalf("%s = %v, want %v (body %v)", k, body[k], v, body)
+		}
+	}
+}
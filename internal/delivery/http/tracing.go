@@ -0,0 +1,48 @@
+// internal/delivery/http/tracing.go
+package http
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startServerSpan은 게이트웨이 요청 하나에 대한 서버 스팬을 시작합니다.
+// 게이트웨이는 gRPC 전송 계층을 거치지 않아 gRPC 서버의 stats handler가 스팬을 만들지 않으므로,
+// HTTP 헤더의 traceparent를 직접 추출해 gRPC 서버와 같은 이름과 속성으로 스팬을 생성합니다.
+func startServerSpan(ctx context.Context, r *http.Request, fullMethod, route string) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	name := strings.TrimPrefix(fullMethod, "/")
+	service, method, _ := strings.Cut(name, "/")
+	return tracing.Start(ctx, "internal/delivery/http", name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+			semconv.HTTPRoute(route),
+		),
+	)
+}
+
+// endServerSpan은 RPC 결과 상태 코드를 기록하고 스팬을 종료합니다.
+// gRPC 서버와 같이 서버 측 오류 코드만 스팬 에러로 표시합니다.
+func endServerSpan(span trace.Span, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(st.Code())))
+	switch st.Code() {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+	span.End()
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// 유스케이스 계층이 반환하는 도메인 에러입니다.
+// 전송 계층(gRPC 등)은 errors.Is로 이 값들을 판별해 클라이언트용 상태 코드로 변환하며,
+// 여기에 해당하지 않는 에러는 내부 에러로 취급되어 메시지가 클라이언트에 노출되지 않습니다.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidClient      = errors.New("invalid client")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidCode        = errors.New("invalid verification code")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrSessionExpired     = errors.New("session expired")
+	ErrSessionLimit       = errors.New("too many active sessions")
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrLocked             = errors.New("account locked")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrFeatureDisabled    = errors.New("feature disabled")
+)
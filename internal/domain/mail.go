@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// EmailCodeTTL은 이메일 인증 코드의 유효 기간입니다. 이 시간이 지난 인증 메일은 발송하지 않습니다.
+const EmailCodeTTL = 10 * time.Minute
+
+// 메일 분류. 분류별로 다른 발송 경로(프로바이더)를 설정할 수 있습니다.
+const (
+	MailCategoryTransactional = "transactional" // 인증 코드 등 일반 트랜잭션 메일
+)
+
+type mailCategoryKey struct{}
+
+// WithMailCategory는 ctx에 발송할 메일의 분류를 담습니다.
+func WithMailCategory(ctx context.Context, category string) context.Context {
+	return context.WithValue(ctx, mailCategoryKey{}, category)
+}
+
+// MailCategoryFromContext는 ctx에 담긴 메일 분류를 반환합니다. 없으면 빈 문자열입니다.
+func MailCategoryFromContext(ctx context.Context) string {
+	category, _ := ctx.Value(mailCategoryKey{}).(string)
+	return category
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// OutboxMessage는 아웃박스에 적재되어 백그라운드 워커가 발송하는 이메일 한 건입니다.
+type OutboxMessage struct {
+	ID             string            `json:"id"`
+	StreamID       string            `json:"-"`                    // 큐 엔트리 ID (Ack/삭제 시 사용, 직렬화하지 않음)
+	IdempotencyKey string            `json:"idempotency_key"`      // 중복 발송 방지 키
+	Category       string            `json:"category,omitempty"`   // 메일 분류 (MailCategory* 상수)
+	RequestID      string            `json:"request_id,omitempty"` // 적재한 요청의 ID (로그 연계용)
+	Trace          map[string]string `json:"trace,omitempty"`      // 적재한 요청의 W3C 트레이스 컨텍스트 (traceparent 등)
+	To             string            `json:"to"`
+	Subject        string            `json:"subject"`
+	Body           string            `json:"body"`
+	Attempts       int               `json:"attempts"`
+	LastError      string            `json:"last_error,omitempty"` // 마지막 발송 실패 사유 (개인정보는 가려서 저장)
+	CreatedAt      time.Time         `json:"created_at"`
+	ExpiresAt      time.Time         `json:"expires_at,omitempty"` // 이 시각 이후에는 발송하지 않음 (인증 코드 만료 등, 0이면 제한 없음)
+}
+
+// Expired는 now 기준으로 메시지 내용이 만료되어 발송할 필요가 없는지 확인합니다.
+func (m *OutboxMessage) Expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// OutboxStats는 아웃박스 큐별 적재 건수입니다.
+type OutboxStats struct {
+	Ready        int64 // 아직 워커가 가져가지 않은 메시지
+	Pending      int64 // 워커가 가져갔지만 Ack 되지 않은 메시지
+	Retrying     int64 // 백오프 후 재시도 대기 중인 메시지
+	DeadLettered int64 // 영구 실패로 데드레터 큐에 이동된 메시지
+}
+
+// ErrPermanentDelivery는 재시도해도 성공할 수 없는 발송 실패(잘못된 수신자 등)를 나타냅니다.
+// 메일러 구현체는 이 에러를 감싸서 반환하면 워커가 재시도 없이 데드레터 처리합니다.
+var ErrPermanentDelivery = errors.New("permanent delivery failure")
@@ -5,5 +5,7 @@ import (
 )
 
 func NewRedis(addr string) *redis.Client {
-	return redis.NewClient(&redis.Options{Addr: addr})
+	rdb := redis.NewClient(&redis.Options{Addr: addr})
+	rdb.AddHook(tracingHook{})
+	return rdb
 }
@@ -0,0 +1,88 @@
+// internal/infra/cache/tracing.go
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "internal/infra/cache"
+
+// tracingHook은 Redis 명령마다 클라이언트 스팬을 생성하는 go-redis 훅입니다.
+// 인증 코드와 토큰이 인자로 전달되므로 명령 인자는 기록하지 않고 명령 이름만 기록합니다.
+//
+// 요청 처리 중인 명령(ctx에 부모 스팬이 있는 경우)만 기록합니다. 아웃박스 폴링, 헬스 체크,
+// 세션 수 집계처럼 주기적으로 실행되는 명령이 각각 루트 트레이스가 되어 트레이스 저장소를 채우지 않도록 합니다.
+type tracingHook struct{}
+
+var _ redis.Hook = tracingHook{}
+
+// spanKey는 훅이 시작한 스팬을 ctx에 담는 키입니다.
+// 스팬을 시작하지 않은 명령에서 trace.SpanFromContext로 부모 스팬을 종료하지 않도록 구분합니다.
+type spanKey struct{}
+
+// startSpan은 ctx에 부모 스팬이 있으면 명령 스팬을 시작합니다.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	ctx, span := tracing.StartClient(ctx, tracerName, name, attrs...)
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// endSpan은 startSpan이 시작한 스팬이 있으면 종료합니다.
+func endSpan(ctx context.Context, err error) {
+	if span, ok := ctx.Value(spanKey{}).(trace.Span); ok {
+		tracing.End(span, err)
+	}
+}
+
+func (tracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	return startSpan(ctx, strings.ToUpper(cmd.Name()),
+		semconv.DBSystemNameRedis,
+		semconv.DBOperationName(strings.ToUpper(cmd.Name())),
+	), nil
+}
+
+func (tracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endSpan(ctx, cmdError(cmd))
+	return nil
+}
+
+func (tracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, len(cmds))
+	for i, cmd := range cmds {
+		names[i] = strings.ToUpper(cmd.Name())
+	}
+	return startSpan(ctx, "PIPELINE",
+		semconv.DBSystemNameRedis,
+		semconv.DBOperationName("PIPELINE"),
+		attribute.StringSlice("db.redis.commands", names),
+	), nil
+}
+
+func (tracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if err = cmdError(cmd); err != nil {
+			break
+		}
+	}
+	endSpan(ctx, err)
+	return nil
+}
+
+// cmdError는 명령 에러를 반환합니다. 키 없음(redis.Nil)은 정상 응답으로 취급합니다.
+func cmdError(cmd redis.Cmder) error {
+	if err := cmd.Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans는 전역 TracerProvider를 스팬 기록기로 교체합니다.
+func recordSpans(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder, tp
+}
+
+func TestTracingHookRequiresParentSpan(t *testing.T) {
+	recorder, tp := recordSpans(t)
+	rdb := NewRedis(miniredis.RunT(t).Addr())
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	// 부모 스팬이 없는 명령 (워커 폴링, 헬스 체크)은 기록하지 않음
+	bg := context.Background()
+	if err := rdb.Ping(bg).Err(); err != nil {
+		t.Fatal(err)
+	}
+	pipe := rdb.Pipeline()
+	pipe.Get(bg, "missing")
+	_, _ = pipe.Exec(bg)
+	if spans := recorder.Ended(); len(spans) != 0 {
+		t.Fatalf("recorded %d spans without a parent, want 0", len(spans))
+	}
+
+	// 요청 처리 중인 명령은 부모 스팬의 자식으로 기록
+	ctx, parent := tp.Tracer("test").Start(bg, "Login")
+	if err := rdb.Set(ctx, "k", "secret-code", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	pipe = rdb.Pipeline()
+	pipe.Get(ctx, "k")
+	pipe.Get(ctx, "missing")
+	_, _ = pipe.Exec(ctx)
+
+	ended := recorder.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("recorded %d spans, want SET and PIPELINE", len(ended))
+	}
+	for i, want := range []string{"SET", "PIPELINE"} {
+		span := ended[i]
+		if span.Name() != want {
+			t.Fatalf("span %d = %s, want %s", i, span.Name(), want)
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Fatalf("%s span is not a child of the request span", want)
+		}
+		// 키 없음은 에러가 아님
+		if span.Status().Code != 0 {
+			t.Fatalf("%s span status = %v", want, span.Status())
+		}
+	}
+
+	// 훅이 부모 스팬을 종료하지 않음
+	if !parent.IsRecording() {
+		t.Fatal("request span was ended by the redis hook")
+	}
+	parent.End()
+}
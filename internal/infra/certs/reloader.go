@@ -0,0 +1,209 @@
+// internal/infra/certs/reloader.go
+// 이 파일은 서버 인증서와 클라이언트 CA 번들을 디스크에서 읽고,
+// 파일이 바뀌면 재시작 없이 다시 로드하는 TLS 설정을 정의합니다.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+var (
+	certExpiry = metrics.Factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "tls_certificate_expiry_timestamp_seconds",
+			Help: "Expiry time of the currently served TLS certificate in unix seconds",
+		},
+	)
+
+	certReloadsTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tls_certificate_reloads_total",
+			Help: "Total number of TLS certificate reload attempts by result",
+		},
+		[]string{"result"},
+	)
+)
+
+// ClientAuthMode는 클라이언트 인증서 검증 방식입니다.
+type ClientAuthMode string
+
+const (
+	ClientAuthNone     ClientAuthMode = "none"     // 클라이언트 인증서를 요청하지 않음
+	ClientAuthOptional ClientAuthMode = "optional" // 인증서를 제시하면 CA로 검증 (미제시 허용)
+	ClientAuthRequire  ClientAuthMode = "require"  // 검증된 인증서가 없으면 연결 거부 (mTLS)
+)
+
+// Config는 서버 TLS 설정입니다.
+type Config struct {
+	CertFile       string
+	KeyFile        string
+	ClientCAFile   string         // 클라이언트 인증서 검증용 CA 번들 (ClientAuth가 none이 아니면 필수)
+	ClientAuth     ClientAuthMode // 기본값 none
+	ReloadInterval time.Duration  // 파일 변경 확인 주기
+}
+
+// material은 한 시점에 로드된 인증서와 CA 풀입니다.
+type material struct {
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime map[string]time.Time
+}
+
+// Reloader는 인증서 파일을 주기적으로 확인해 변경되면 다시 로드합니다.
+// 새 파일을 읽는 데 실패하면 기존 인증서를 계속 사용합니다.
+type Reloader struct {
+	cfg     Config
+	log     *zap.Logger
+	current atomic.Pointer[material]
+}
+
+// NewReloader 생성자 함수는 인증서를 처음 로드하고 Reloader를 반환합니다.
+func NewReloader(cfg Config, log *zap.Logger) (*Reloader, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, errors.New("tls: cert file and key file are required")
+	}
+	if cfg.ClientAuth == "" {
+		cfg.ClientAuth = ClientAuthNone
+	}
+	switch cfg.ClientAuth {
+	case ClientAuthNone:
+	case ClientAuthOptional, ClientAuthRequire:
+		if cfg.ClientCAFile == "" {
+			return nil, fmt.Errorf("tls: client auth %q requires a client CA file", cfg.ClientAuth)
+		}
+	default:
+		return nil, fmt.Errorf("tls: unknown client auth mode %q", cfg.ClientAuth)
+	}
+	if cfg.ReloadInterval <= 0 {
+		cfg.ReloadInterval = 30 * time.Second
+	}
+
+	r := &Reloader{cfg: cfg, log: log}
+	m, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.store(m)
+	return r, nil
+}
+
+// ServerConfig는 핸드셰이크마다 최신 인증서와 CA 풀을 사용하는 TLS 설정을 반환합니다.
+func (r *Reloader) ServerConfig() *tls.Config {
+	clientAuth := tls.NoClientCert
+	switch r.cfg.ClientAuth {
+	case ClientAuthOptional:
+		clientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: clientAuth,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			m := r.current.Load()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				ClientAuth:   clientAuth,
+				Certificates: []tls.Certificate{*m.cert},
+				ClientCAs:    m.pool,
+			}, nil
+		},
+	}
+}
+
+// Run은 ctx가 취소될 때까지 파일 변경을 확인합니다.
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.ReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadIfChanged()
+		}
+	}
+}
+
+// reloadIfChanged는 파일 수정 시각이 바뀐 경우에만 다시 로드합니다.
+func (r *Reloader) reloadIfChanged() {
+	prev := r.current.Load()
+	changed := false
+	for path, t := range prev.modTime {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(t) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+
+	m, err := r.load()
+	if err != nil {
+		certReloadsTotal.WithLabelValues("failure").Inc()
+		r.log.Error("tls certificate reload failed, keeping previous certificate", zap.Error(err))
+		return
+	}
+	r.store(m)
+	certReloadsTotal.WithLabelValues("success").Inc()
+	r.log.Info("tls certificate reloaded", zap.Time("not_after", m.cert.Leaf.NotAfter))
+}
+
+func (r *Reloader) store(m *material) {
+	r.current.Store(m)
+	certExpiry.Set(float64(m.cert.Leaf.NotAfter.Unix()))
+}
+
+// load는 인증서, 키, CA 번들을 읽습니다.
+func (r *Reloader) load() (*material, error) {
+	m := &material{modTime: make(map[string]time.Time)}
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientAuth != ClientAuthNone {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	// 읽기 전에 수정 시각을 기록해, 읽는 도중 파일이 바뀌면 다음 확인에서 다시 로드되도록 함
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("tls: %w", err)
+		}
+		m.modTime[path] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: load key pair: %w", err)
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return nil, fmt.Errorf("tls: parse certificate: %w", err)
+		}
+	}
+	m.cert = &cert
+
+	if r.cfg.ClientAuth != ClientAuthNone {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: read client ca file: %w", err)
+		}
+		m.pool = x509.NewCertPool()
+		if !m.pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", r.cfg.ClientCAFile)
+		}
+	}
+	return m, nil
+}
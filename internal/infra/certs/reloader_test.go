@@ -0,0 +1,152 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// writeCert는 serial 번호를 가진 자체 서명 인증서와 키를 certFile, keyFile에 쓰고 인증서를 반환합니다.
+// 수정 시각은 at으로 설정해 같은 초 안에 다시 써도 변경이 감지되도록 합니다.
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, at time.Time) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "auth.internal"},
+		DNSNames:     []string{"auth.internal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Duration(serial) * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{certFile, keyFile} {
+		if err := os.Chtimes(f, at, at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// servedCert는 r의 서버 설정으로 TLS 핸드셰이크를 하고 서버가 제시한 인증서를 반환합니다.
+func servedCert(t *testing.T, r *Reloader, trusted *x509.Certificate) *x509.Certificate {
+	t.Helper()
+	serverConn, clientConn := net.Pipe()
+	defer clientConn.Close()
+	go func() {
+		defer serverConn.Close()
+		_ = tls.Server(serverConn, r.ServerConfig()).Handshake()
+	}()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(trusted)
+	client := tls.Client(clientConn, &tls.Config{ServerName: "auth.internal", RootCAs: roots, MinVersion: tls.VersionTLS12})
+	if err := client.Handshake(); err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	return client.ConnectionState().PeerCertificates[0]
+}
+
+func TestReloaderPicksUpRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	start := time.Now().Add(-time.Minute)
+	first := writeCert(t, certFile, keyFile, 1, start)
+
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := servedCert(t, r, first); got.SerialNumber.Int64() != 1 {
+		t.Fatalf("serving serial %v, want 1", got.SerialNumber)
+	}
+
+	// 파일이 바뀌지 않으면 다시 읽지 않음
+	before := r.current.Load()
+	r.reloadIfChanged()
+	if r.current.Load() != before {
+		t.Fatal("reloaded unchanged files")
+	}
+
+	// 인증서 교체 후 재시작 없이 새 인증서 제공
+	second := writeCert(t, certFile, keyFile, 2, start.Add(time.Second))
+	r.reloadIfChanged()
+	if got := servedCert(t, r, second); got.SerialNumber.Int64() != 2 {
+		t.Fatalf("serving serial %v after rotation, want 2", got.SerialNumber)
+	}
+
+	// 잘못된 파일로 교체되면 이전 인증서를 계속 사용
+	if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(certFile, start.Add(2*time.Second), start.Add(2*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	r.reloadIfChanged()
+	if got := servedCert(t, r, second); got.SerialNumber.Int64() != 2 {
+		t.Fatalf("serving serial %v after a broken rotation, want 2", got.SerialNumber)
+	}
+}
+
+func TestNewReloaderConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	writeCert(t, certFile, keyFile, 1, time.Now())
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"server only", Config{CertFile: certFile, KeyFile: keyFile}, false},
+		{"client auth with ca", Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: ClientAuthRequire, ClientCAFile: certFile}, false},
+		{"missing key", Config{CertFile: certFile}, true},
+		{"client auth without ca", Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: ClientAuthOptional}, true},
+		{"unknown client auth", Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: "always"}, true},
+		{"unreadable ca", Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: ClientAuthRequire, ClientCAFile: filepath.Join(dir, "missing.pem")}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReloader(tt.cfg, zap.NewNop())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewReloader = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+// internal/infra/health/health.go
+// 이 파일은 의존성(Postgres, Redis) 상태를 주기적으로 확인해
+// gRPC Health 서비스와 HTTP /healthz, /readyz 응답에 반영하는 헬스 체커를 정의합니다.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var dependencyUp = metrics.Factory.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "dependency_up",
+		Help: "Whether a dependency passed its last health probe (1) or not (0)",
+	},
+	[]string{"dependency"},
+)
+
+// Probe는 의존성 하나의 상태 확인 함수입니다.
+type Probe struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// 기본 프로브 이름 (NewChecker의 서비스 의존성에 사용)
+const (
+	ProbePostgres = "postgres"
+	ProbeRedis    = "redis"
+)
+
+// PostgresProbe는 커넥션 풀에 Ping을 보내는 프로브를 생성합니다.
+func PostgresProbe(db *sql.DB) Probe {
+	return Probe{Name: ProbePostgres, Check: db.PingContext}
+}
+
+// RedisProbe는 Redis에 PING을 보내는 프로브를 생성합니다.
+func RedisProbe(rdb *redis.Client) Probe {
+	return Probe{Name: ProbeRedis, Check: func(ctx context.Context) error {
+		return rdb.Ping(ctx).Err()
+	}}
+}
+
+// Config는 프로브 실행 주기 설정입니다.
+type Config struct {
+	Interval time.Duration // 프로브 실행 주기
+	Timeout  time.Duration // 프로브 한 번의 타임아웃
+}
+
+// Checker는 프로브 결과에 따라 gRPC Health 서비스의 상태를 갱신합니다.
+type Checker struct {
+	server   *health.Server
+	services map[string][]string // 서비스 이름 → 의존하는 프로브 이름
+	probes   []Probe
+	cfg      Config
+	log      *zap.Logger
+
+	mu       sync.RWMutex
+	failures map[string]string // 프로브 이름 → 마지막 실패 사유
+	checked  bool              // 첫 번째 프로브가 끝났는지 여부
+	shutdown bool
+}
+
+// NewChecker 생성자 함수는 services(gRPC 서비스 전체 이름 → 의존하는 프로브 이름)의 상태를
+// probes 결과로 관리하는 Checker를 생성합니다. 각 서비스는 자신이 의존하는 프로브가 실패할 때만 NOT_SERVING이 되며,
+// 전체 서버("")는 프로브 중 하나라도 실패하면 NOT_SERVING입니다.
+// 모든 서비스는 첫 번째 프로브가 끝날 때까지 NOT_SERVING 상태입니다.
+func NewChecker(services map[string][]string, probes []Probe, cfg Config, log *zap.Logger) (*Checker, error) {
+	known := make(map[string]bool, len(probes))
+	for _, p := range probes {
+		known[p.Name] = true
+	}
+	for svc, deps := range services {
+		for _, dep := range deps {
+			// 오타가 난 의존성은 실패해도 서비스 상태에 반영되지 않으므로 시작 시점에 거부
+			if !known[dep] {
+				return nil, fmt.Errorf("health: service %s depends on unknown probe %q", svc, dep)
+			}
+		}
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 5 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second
+	}
+
+	c := &Checker{
+		server:   health.NewServer(),
+		services: services,
+		probes:   probes,
+		cfg:      cfg,
+		log:      log,
+		failures: make(map[string]string),
+	}
+	c.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for svc := range services {
+		c.server.SetServingStatus(svc, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return c, nil
+}
+
+// Server는 grpc.health.v1.Health 서비스 구현을 반환합니다.
+func (c *Checker) Server() healthpb.HealthServer {
+	return c.server
+}
+
+// Run은 ctx가 취소될 때까지 주기적으로 프로브를 실행합니다.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		c.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown은 모든 서비스를 NOT_SERVING으로 고정합니다. 이후 프로브 결과는 무시됩니다.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	c.shutdown = true
+	c.mu.Unlock()
+	c.server.Shutdown()
+}
+
+// probe는 모든 프로브를 병렬로 실행하고 결과를 반영합니다.
+func (c *Checker) probe(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.Timeout)
+	defer cancel()
+
+	failures := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, p := range c.probes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := p.Check(ctx)
+
+			up := 1.0
+			if err != nil {
+				up = 0
+				mu.Lock()
+				failures[p.Name] = err.Error()
+				mu.Unlock()
+			}
+			dependencyUp.WithLabelValues(p.Name).Set(up)
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shutdown {
+		return
+	}
+	for name, reason := range failures {
+		if _, known := c.failures[name]; !known {
+			c.log.Warn("dependency unhealthy", zap.String("dependency", name), zap.String("error", reason))
+		}
+	}
+	for name := range c.failures {
+		if _, still := failures[name]; !still {
+			c.log.Info("dependency recovered", zap.String("dependency", name))
+		}
+	}
+	c.failures = failures
+	c.checked = true
+	c.setStatus()
+}
+
+// setStatus는 현재 실패 목록에 따라 전체 서버("")와 서비스별 상태를 변경합니다.
+func (c *Checker) setStatus() {
+	c.server.SetServingStatus("", servingStatus(len(c.failures) == 0))
+	for svc, deps := range c.services {
+		healthy := true
+		for _, dep := range deps {
+			if _, failed := c.failures[dep]; failed {
+				healthy = false
+				break
+			}
+		}
+		c.server.SetServingStatus(svc, servingStatus(healthy))
+	}
+}
+
+func servingStatus(healthy bool) healthpb.HealthCheckResponse_ServingStatus {
+	if healthy {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
+
+// readyResponse는 /readyz 응답 본문입니다.
+// 인증 없이 노출되므로 의존성 에러 내용은 담지 않습니다 (프로브 실패는 로그로 확인).
+type readyResponse struct {
+	Status string `json:"status"`
+}
+
+// LivenessHandler는 프로세스가 요청을 처리할 수 있으면 항상 200을 반환합니다 (/healthz).
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok\n"))
+	})
+}
+
+// ReadinessHandler는 모든 의존성이 정상이고 종료 중이 아닐 때만 200을 반환합니다 (/readyz).
+// 그 외에는 503과 함께 상태(unavailable, shutting_down)만 반환합니다.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		resp := readyResponse{Status: "ok"}
+		code := http.StatusOK
+		switch {
+		case c.shutdown:
+			resp.Status, code = "shutting_down", http.StatusServiceUnavailable
+		case !c.checked || len(c.failures) > 0:
+			resp.Status, code = "unavailable", http.StatusServiceUnavailable
+		}
+		c.mu.RUnlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
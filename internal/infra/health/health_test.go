@@ -0,0 +1,135 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func readyz(t *testing.T, c *Checker) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func TestReadinessHidesDependencyErrors(t *testing.T) {
+	var failing error
+	probe := Probe{Name: "postgres", Check: func(context.Context) error { return failing }}
+	c, err := NewChecker(nil, []Probe{probe}, Config{}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code, body := readyz(t, c); code != http.StatusServiceUnavailable || body != `{"status":"unavailable"}` {
+		t.Fatalf("before first probe: %d %s", code, body)
+	}
+
+	failing = errors.New("dial tcp 10.0.0.5:5432: password authentication failed for user \"auth\"")
+	c.probe(context.Background())
+	code, body := readyz(t, c)
+	if code != http.StatusServiceUnavailable || body != `{"status":"unavailable"}` {
+		t.Fatalf("failing probe: %d %s", code, body)
+	}
+	if strings.Contains(body, "postgres") || strings.Contains(body, "10.0.0.5") {
+		t.Fatalf("readiness body leaks dependency details: %s", body)
+	}
+
+	failing = nil
+	c.probe(context.Background())
+	if code, body := readyz(t, c); code != http.StatusOK || body != `{"status":"ok"}` {
+		t.Fatalf("healthy: %d %s", code, body)
+	}
+
+	c.Shutdown()
+	if code, body := readyz(t, c); code != http.StatusServiceUnavailable || body != `{"status":"shutting_down"}` {
+		t.Fatalf("shutting down: %d %s", code, body)
+	}
+}
+
+// grpcStatus는 gRPC Health 서비스가 svc에 대해 응답하는 상태를 반환합니다.
+func grpcStatus(t *testing.T, c *Checker, svc string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := c.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: svc})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", svc, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestServiceStatusFollowsDependencies(t *testing.T) {
+	const (
+		serving    = healthpb.HealthCheckResponse_SERVING
+		notServing = healthpb.HealthCheckResponse_NOT_SERVING
+	)
+	services := map[string][]string{
+		"auth.AuthService":  {ProbePostgres, ProbeRedis},
+		"auth.AdminService": {ProbeRedis},
+	}
+	tests := []struct {
+		name   string
+		failed []string
+		want   map[string]healthpb.HealthCheckResponse_ServingStatus // "" = 전체 서버
+	}{
+		{"all healthy", nil, map[string]healthpb.HealthCheckResponse_ServingStatus{"": serving, "auth.AuthService": serving, "auth.AdminService": serving}},
+		{"postgres down", []string{ProbePostgres}, map[string]healthpb.HealthCheckResponse_ServingStatus{"": notServing, "auth.AuthService": notServing, "auth.AdminService": serving}},
+		{"redis down", []string{ProbeRedis}, map[string]healthpb.HealthCheckResponse_ServingStatus{"": notServing, "auth.AuthService": notServing, "auth.AdminService": notServing}},
+		{"both down", []string{ProbePostgres, ProbeRedis}, map[string]healthpb.HealthCheckResponse_ServingStatus{"": notServing, "auth.AuthService": notServing, "auth.AdminService": notServing}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failed := make(map[string]bool)
+			for _, name := range tt.failed {
+				failed[name] = true
+			}
+			probe := func(name string) Probe {
+				return Probe{Name: name, Check: func(context.Context) error {
+					if failed[name] {
+						return errors.New(name + " unreachable")
+					}
+					return nil
+				}}
+			}
+			c, err := NewChecker(services, []Probe{probe(ProbePostgres), probe(ProbeRedis)}, Config{}, zap.NewNop())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 첫 번째 프로브 전에는 모두 NOT_SERVING
+			for svc := range tt.want {
+				if got := grpcStatus(t, c, svc); got != notServing {
+					t.Fatalf("%q before first probe = %v", svc, got)
+				}
+			}
+
+			c.probe(context.Background())
+			for svc, want := range tt.want {
+				if got := grpcStatus(t, c, svc); got != want {
+					t.Fatalf("%q = %v, want %v", svc, got, want)
+				}
+			}
+
+			// 복구되면 모두 SERVING
+			clear(failed)
+			c.probe(context.Background())
+			for svc := range tt.want {
+				if got := grpcStatus(t, c, svc); got != serving {
+					t.Fatalf("%q after recovery = %v", svc, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCheckerRejectsUnknownDependency(t *testing.T) {
+	probes := []Probe{{Name: ProbeRedis, Check: func(context.Context) error { return nil }}}
+	_, err := NewChecker(map[string][]string{"auth.AdminService": {"rediss"}}, probes, Config{}, zap.NewNop())
+	if err == nil || !strings.Contains(err.Error(), `"rediss"`) {
+		t.Fatalf("err = %v, want unknown probe rejected", err)
+	}
+}
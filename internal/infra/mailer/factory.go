@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"github.com/aquaheyday/go-auth-service/pkg/config"
+	"go.uber.org/zap"
+)
+
+// 설정에서 사용하는 프로바이더 이름
+const (
+	ProviderSMTP     = "smtp"
+	ProviderSendGrid = "sendgrid"
+	ProviderLog      = "log"
+	ProviderFile     = "file"
+)
+
+// NewFromConfig는 설정에 있는 프로바이더들을 생성하고 이를 묶은 Router를 반환합니다.
+// 자격 증명이 설정된 프로바이더만 생성되며, 경로에서 생성되지 않은 프로바이더를 참조하면 에러를 반환합니다.
+func NewFromConfig(cfg *config.Config, log *zap.Logger) (*Router, error) {
+	providers := map[string]Sender{
+		ProviderLog: NewLogMailer(log),
+	}
+
+	if cfg.SMTP.Host != "" {
+		smtpMailer, err := NewSMTPMailer(SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.User,
+			Password: cfg.SMTP.Pass,
+			From:     cfg.SMTP.From,
+			TLSMode:  TLSMode(cfg.SMTP.TLSMode),
+			CAFile:   cfg.SMTP.CAFile,
+			PoolSize: cfg.SMTP.PoolSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers[ProviderSMTP] = smtpMailer
+	}
+
+	if cfg.SendGrid.APIKey != "" {
+		providers[ProviderSendGrid] = NewSendGridMailer(
+			cfg.SendGrid.APIKey,
+			cfg.SendGrid.FromEmail,
+			cfg.SendGrid.FromName,
+			cfg.SendGrid.Sandbox,
+		)
+	}
+
+	if cfg.Mail.FileDir != "" {
+		fileMailer, err := NewFileMailer(cfg.Mail.FileDir, cfg.SMTP.From)
+		if err != nil {
+			return nil, err
+		}
+		providers[ProviderFile] = fileMailer
+	}
+
+	defaults, routes := RoutingFromConfig(cfg)
+	return NewRouter(providers, RouterConfig{Default: defaults, Routes: routes}, log)
+}
+
+// RoutingFromConfig는 설정의 기본/보조 프로바이더와 분류별 경로를 Router 경로로 변환합니다.
+func RoutingFromConfig(cfg *config.Config) (defaults []string, routes map[string][]string) {
+	defaults = []string{cfg.Mail.Primary}
+	if cfg.Mail.Secondary != "" {
+		defaults = append(defaults, cfg.Mail.Secondary)
+	}
+	return defaults, cfg.Mail.Routes
+}
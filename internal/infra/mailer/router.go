@@ -0,0 +1,274 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const tracerName = "internal/infra/mailer"
+
+var (
+	providerHealthy = metrics.Factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mail_provider_healthy",
+			Help: "Whether a mail provider is currently considered healthy (1) or not (0)",
+		},
+		[]string{"provider"},
+	)
+
+	providerSendDuration = metrics.NewLatencyHistogram(
+		"mail_provider_send_duration_seconds",
+		"Latency of mail provider send calls by provider and result",
+		"provider", "result",
+	)
+
+	providerFailures = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mail_provider_failures_total",
+			Help: "Total number of failed mail provider send calls by provider",
+		},
+		[]string{"provider"},
+	)
+)
+
+// Sender는 라우터가 사용하는 메일 프로바이더입니다.
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// RouterConfig는 프로바이더 선택 규칙입니다.
+type RouterConfig struct {
+	// Default는 분류별 경로가 없을 때 사용할 프로바이더 순서입니다 (primary, secondary, ...).
+	Default []string
+	// Routes는 메일 분류(domain.MailCategory*)별 프로바이더 순서입니다.
+	Routes map[string][]string
+	// FailureThreshold번 연속 실패하면 프로바이더를 비정상으로 표시합니다.
+	FailureThreshold int
+	// Cooldown 동안 비정상 프로바이더는 건너뛰고, 이후 한 번씩 다시 시도합니다.
+	Cooldown time.Duration
+}
+
+// ProviderHealth는 프로바이더의 현재 상태입니다.
+type ProviderHealth struct {
+	Name                string
+	Healthy             bool
+	ConsecutiveFailures int
+	LastError           string
+	LastFailure         time.Time
+}
+
+type providerState struct {
+	failures    int
+	lastError   string
+	lastFailure time.Time
+}
+
+// Router는 분류별 경로에 따라 프로바이더를 선택하고, 실패 시 다음 프로바이더로 넘어가는 메일러입니다.
+type Router struct {
+	providers map[string]Sender
+	cfg       RouterConfig
+	log       *zap.Logger
+	now       func() time.Time
+
+	mu    sync.Mutex
+	state map[string]*providerState
+}
+
+func NewRouter(providers map[string]Sender, cfg RouterConfig, log *zap.Logger) (*Router, error) {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 3
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second
+	}
+
+	if err := checkRouting(providers, cfg.Default, cfg.Routes); err != nil {
+		return nil, err
+	}
+
+	state := make(map[string]*providerState, len(providers))
+	for name := range providers {
+		state[name] = &providerState{}
+		providerHealthy.WithLabelValues(name).Set(1)
+	}
+	return &Router{providers: providers, cfg: cfg, log: log, now: time.Now, state: state}, nil
+}
+
+// checkRouting은 경로가 생성된 프로바이더만 가리키는지 확인합니다.
+func checkRouting(providers map[string]Sender, defaults []string, routes map[string][]string) error {
+	if len(defaults) == 0 {
+		return errors.New("mail router: no default provider configured")
+	}
+	for _, names := range append([][]string{defaults}, slices.Collect(maps.Values(routes))...) {
+		for _, name := range names {
+			if _, ok := providers[name]; !ok {
+				return fmt.Errorf("mail router: provider %q is not configured", name)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckRouting은 SetRouting으로 바꿀 경로가 유효한지 미리 확인합니다.
+func (r *Router) CheckRouting(defaults []string, routes map[string][]string) error {
+	return checkRouting(r.providers, defaults, routes)
+}
+
+// SetRouting은 기본 경로와 분류별 경로를 교체합니다 (설정 재로드).
+// 프로바이더 목록과 상태는 유지되므로, 생성되지 않은 프로바이더는 가리킬 수 없습니다.
+func (r *Router) SetRouting(defaults []string, routes map[string][]string) error {
+	if err := r.CheckRouting(defaults, routes); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cfg.Default = defaults
+	r.cfg.Routes = routes
+	return nil
+}
+
+// Send는 ctx의 메일 분류에 해당하는 경로를 따라 발송을 시도합니다.
+// 수신자 거부 등 영구 실패는 다른 프로바이더로도 성공할 수 없으므로 바로 반환합니다.
+func (r *Router) Send(ctx context.Context, to, subject, body string) (err error) {
+	category := domain.MailCategoryFromContext(ctx)
+	ctx, span := tracing.Start(ctx, tracerName, "mail.Router.Send", trace.WithAttributes(
+		attribute.String("mail.category", category),
+	))
+	defer func() { tracing.End(span, err) }()
+
+	var errs []error
+	for _, name := range r.candidates(category) {
+		err := r.sendWith(ctx, name, to, subject, body)
+		if err == nil {
+			r.recordSuccess(name)
+			return nil
+		}
+		if errors.Is(err, domain.ErrPermanentDelivery) {
+			return err
+		}
+
+		r.recordFailure(name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		if ctx.Err() != nil {
+			break
+		}
+		r.log.Warn("mail provider failed, failing over", zap.String("provider", name), zap.Error(err))
+	}
+	return fmt.Errorf("mail router: all providers failed: %w", errors.Join(errs...))
+}
+
+// sendWith는 프로바이더 하나로 발송하며, 시도마다 클라이언트 스팬을 남깁니다 (수신자는 기록하지 않음).
+func (r *Router) sendWith(ctx context.Context, name, to, subject, body string) (err error) {
+	ctx, span := tracing.StartClient(ctx, tracerName, "mail.send "+name,
+		attribute.String("mail.provider", name),
+	)
+	start := time.Now()
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "failure"
+			providerFailures.WithLabelValues(name).Inc()
+		}
+		providerSendDuration.Observe(ctx, time.Since(start).Seconds(), name, result)
+		tracing.End(span, err)
+	}()
+	return r.providers[name].Send(ctx, to, subject, body)
+}
+
+// candidates는 시도할 프로바이더 순서를 반환합니다.
+// 쿨다운 중인 비정상 프로바이더는 뒤로 보내, 정상 프로바이더가 모두 실패했을 때만 시도합니다.
+func (r *Router) candidates(category string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	route, ok := r.cfg.Routes[category]
+	if !ok || len(route) == 0 {
+		route = r.cfg.Default
+	}
+
+	now := r.now()
+	available := make([]string, 0, len(route))
+	var coolingDown []string
+	for _, name := range route {
+		st := r.state[name]
+		if st.failures >= r.cfg.FailureThreshold && now.Sub(st.lastFailure) < r.cfg.Cooldown {
+			coolingDown = append(coolingDown, name)
+			continue
+		}
+		available = append(available, name)
+	}
+	return append(available, coolingDown...)
+}
+
+func (r *Router) recordSuccess(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := r.state[name]
+	if st.failures >= r.cfg.FailureThreshold {
+		r.log.Info("mail provider recovered", zap.String("provider", name))
+	}
+	st.failures = 0
+	st.lastError = ""
+	providerHealthy.WithLabelValues(name).Set(1)
+}
+
+func (r *Router) recordFailure(name string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := r.state[name]
+	st.failures++
+	st.lastError = err.Error()
+	st.lastFailure = r.now()
+	if st.failures == r.cfg.FailureThreshold {
+		r.log.Error("mail provider marked unhealthy", zap.String("provider", name), zap.Error(err))
+	}
+	if st.failures >= r.cfg.FailureThreshold {
+		providerHealthy.WithLabelValues(name).Set(0)
+	}
+}
+
+// Health는 프로바이더별 상태를 이름순으로 반환합니다.
+func (r *Router) Health() []ProviderHealth {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	health := make([]ProviderHealth, 0, len(r.state))
+	for name, st := range r.state {
+		health = append(health, ProviderHealth{
+			Name:                name,
+			Healthy:             st.failures < r.cfg.FailureThreshold,
+			ConsecutiveFailures: st.failures,
+			LastError:           st.lastError,
+			LastFailure:         st.lastFailure,
+		})
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Name < health[j].Name })
+	return health
+}
+
+// Close는 연결을 보유한 프로바이더(SMTP 연결 풀 등)를 정리합니다.
+func (r *Router) Close() error {
+	var errs []error
+	for _, p := range r.providers {
+		if c, ok := p.(interface{ Close() error }); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
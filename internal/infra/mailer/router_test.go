@@ -0,0 +1,180 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// fakeSender는 호출 횟수를 세고 err를 반환하는 테스트용 프로바이더입니다.
+type fakeSender struct {
+	err   error
+	calls int
+}
+
+func (f *fakeSender) Send(context.Context, string, string, string) error {
+	f.calls++
+	return f.err
+}
+
+// testClock은 테스트에서 쿨다운 경과를 제어하는 시계입니다.
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time { return c.t }
+
+func newTestRouter(t *testing.T, providers map[string]Sender, cfg RouterConfig) (*Router, *testClock) {
+	t.Helper()
+	r, err := NewRouter(providers, cfg, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := &testClock{t: time.Unix(1_700_000_000, 0)}
+	r.now = clock.now
+	return r, clock
+}
+
+func healthOf(r *Router, name string) ProviderHealth {
+	for _, h := range r.Health() {
+		if h.Name == name {
+			return h
+		}
+	}
+	return ProviderHealth{}
+}
+
+func TestRouterFailsOverToSecondary(t *testing.T) {
+	primary := &fakeSender{err: errors.New("connection refused")}
+	secondary := &fakeSender{}
+	r, _ := newTestRouter(t, map[string]Sender{"primary": primary, "secondary": secondary},
+		RouterConfig{Default: []string{"primary", "secondary"}})
+
+	if err := r.Send(context.Background(), "user@example.com", "s", "b"); err != nil {
+		t.Fatalf("Send = %v, want success via secondary", err)
+	}
+	if primary.calls != 1 || secondary.calls != 1 {
+		t.Fatalf("calls primary=%d secondary=%d, want 1/1", primary.calls, secondary.calls)
+	}
+	if h := healthOf(r, "primary"); h.ConsecutiveFailures != 1 || h.LastError == "" {
+		t.Fatalf("primary health = %+v, want one recorded failure", h)
+	}
+}
+
+func TestRouterAllProvidersFail(t *testing.T) {
+	primary := &fakeSender{err: errors.New("timeout")}
+	secondary := &fakeSender{err: errors.New("503")}
+	r, _ := newTestRouter(t, map[string]Sender{"primary": primary, "secondary": secondary},
+		RouterConfig{Default: []string{"primary", "secondary"}})
+
+	err := r.Send(context.Background(), "user@example.com", "s", "b")
+	if err == nil || errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("Send = %v, want retryable error", err)
+	}
+}
+
+func TestRouterPermanentFailureDoesNotFailOver(t *testing.T) {
+	primary := &fakeSender{err: fmt.Errorf("%w: smtp: 550 mailbox unavailable", domain.ErrPermanentDelivery)}
+	secondary := &fakeSender{}
+	r, _ := newTestRouter(t, map[string]Sender{"primary": primary, "secondary": secondary},
+		RouterConfig{Default: []string{"primary", "secondary"}})
+
+	err := r.Send(context.Background(), "gone@example.com", "s", "b")
+	if !errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("Send = %v, want ErrPermanentDelivery", err)
+	}
+	if secondary.calls != 0 {
+		t.Fatal("permanent rejection was retried on the secondary provider")
+	}
+	if h := healthOf(r, "primary"); h.ConsecutiveFailures != 0 {
+		t.Fatalf("recipient rejection counted against provider health: %+v", h)
+	}
+}
+
+func TestRouterCooldownSkipsUnhealthyProviderThenRecovers(t *testing.T) {
+	primary := &fakeSender{err: errors.New("auth failed")}
+	secondary := &fakeSender{}
+	r, clock := newTestRouter(t, map[string]Sender{"primary": primary, "secondary": secondary},
+		RouterConfig{Default: []string{"primary", "secondary"}, FailureThreshold: 2, Cooldown: time.Minute})
+	ctx := context.Background()
+
+	for range 2 {
+		if err := r.Send(ctx, "user@example.com", "s", "b"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if h := healthOf(r, "primary"); h.Healthy {
+		t.Fatalf("primary still healthy after %d failures", h.ConsecutiveFailures)
+	}
+
+	// 쿨다운 중에는 비정상 프로바이더를 건너뜀
+	if err := r.Send(ctx, "user@example.com", "s", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if primary.calls != 2 {
+		t.Fatalf("primary called %d times during cooldown, want 2", primary.calls)
+	}
+
+	// 쿨다운이 지나면 다시 시도하고, 성공하면 정상으로 복구
+	clock.t = clock.t.Add(time.Minute)
+	primary.err = nil
+	if err := r.Send(ctx, "user@example.com", "s", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if primary.calls != 3 {
+		t.Fatalf("primary called %d times after cooldown, want 3", primary.calls)
+	}
+	if h := healthOf(r, "primary"); !h.Healthy || h.ConsecutiveFailures != 0 {
+		t.Fatalf("primary health after recovery = %+v", h)
+	}
+}
+
+func TestRouterTriesCoolingDownProviderLast(t *testing.T) {
+	primary := &fakeSender{err: errors.New("down")}
+	secondary := &fakeSender{}
+	r, _ := newTestRouter(t, map[string]Sender{"primary": primary, "secondary": secondary},
+		RouterConfig{Default: []string{"primary", "secondary"}, FailureThreshold: 1, Cooldown: time.Minute})
+
+	_ = r.Send(context.Background(), "user@example.com", "s", "b")
+	if got := r.candidates(""); len(got) != 2 || got[0] != "secondary" || got[1] != "primary" {
+		t.Fatalf("candidates = %v, want [secondary primary]", got)
+	}
+}
+
+func TestRouterCategoryRouting(t *testing.T) {
+	smtpSender := &fakeSender{}
+	sendgridSender := &fakeSender{}
+	r, _ := newTestRouter(t, map[string]Sender{"smtp": smtpSender, "sendgrid": sendgridSender}, RouterConfig{
+		Default: []string{"smtp"},
+		Routes:  map[string][]string{domain.MailCategoryTransactional: {"sendgrid", "smtp"}},
+	})
+
+	ctx := domain.WithMailCategory(context.Background(), domain.MailCategoryTransactional)
+	if err := r.Send(ctx, "user@example.com", "s", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if sendgridSender.calls != 1 || smtpSender.calls != 0 {
+		t.Fatalf("routed mail: sendgrid=%d smtp=%d, want 1/0", sendgridSender.calls, smtpSender.calls)
+	}
+
+	// 경로가 없는 분류는 기본 경로를 사용
+	if err := r.Send(domain.WithMailCategory(context.Background(), "other"), "user@example.com", "s", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if smtpSender.calls != 1 {
+		t.Fatalf("unrouted mail: smtp=%d, want 1", smtpSender.calls)
+	}
+}
+
+func TestRouterRejectsUnknownProviderInRoutes(t *testing.T) {
+	_, err := NewRouter(map[string]Sender{"smtp": &fakeSender{}}, RouterConfig{
+		Default: []string{"smtp"},
+		Routes:  map[string][]string{domain.MailCategoryTransactional: {"sendgrid"}},
+	}, zap.NewNop())
+	if err == nil {
+		t.Fatal("NewRouter accepted a route to an unconfigured provider")
+	}
+}
@@ -1,10 +1,14 @@
 package mailer
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
+	"github.com/aquaheyday/go-auth-service/internal/domain"
 	"github.com/sendgrid/sendgrid-go"
 	sgmail "github.com/sendgrid/sendgrid-go/helpers/mail"
 )
@@ -30,7 +34,7 @@ func boolPtr(b bool) *bool { return &b }
 
 // UseCase에서 요구하는 시그니처: 텍스트 바디 하나만 받음
 // 여기서 텍스트를 기반으로 HTML 바디를 자동으로 만들어 같이 보냅니다.
-func (m *SendGridMailer) Send(to, subject, body string) error {
+func (m *SendGridMailer) Send(ctx context.Context, to, subject, body string) error {
 	if m.apiKey == "" {
 		m.apiKey = os.Getenv("SENDGRID_API_KEY")
 	}
@@ -66,14 +70,42 @@ func (m *SendGridMailer) Send(to, subject, body string) error {
 	}
 
 	client := sendgrid.NewSendClient(m.apiKey)
-	resp, err := client.Send(msg)
+	resp, err := client.SendWithContext(ctx, msg)
 	if err != nil {
 		return fmt.Errorf("sendgrid send error: %w", err)
 	}
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("sendgrid response error: status=%d body=%s", resp.StatusCode, resp.Body)
+	return classifySendGridResponse(resp.StatusCode, resp.Body)
+}
+
+// classifySendGridResponse는 응답 상태를 에러로 변환합니다.
+// 수신자 주소를 거부한 400만 영구 실패로 분류하고, 401/403(API 키 문제), 429, 5xx 등
+// 그 밖의 실패는 프로바이더 장애로 보고 다른 프로바이더로 넘깁니다.
+func classifySendGridResponse(status int, body string) error {
+	if status < 400 {
+		return nil
+	}
+	if status == http.StatusBadRequest && sendgridRecipientRejected(body) {
+		return fmt.Errorf("%w: sendgrid response error: status=%d body=%s", domain.ErrPermanentDelivery, status, body)
+	}
+	return fmt.Errorf("sendgrid response error: status=%d body=%s", status, body)
+}
+
+// sendgridRecipientRejected는 에러 응답이 수신자(personalizations) 필드를 가리키는지 확인합니다.
+func sendgridRecipientRejected(body string) bool {
+	var resp struct {
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return false
+	}
+	for _, e := range resp.Errors {
+		if strings.HasPrefix(e.Field, "personalizations") {
+			return true
+		}
 	}
-	return nil
+	return false
 }
 
 // 아주 간단한 HTML 이스케이프
@@ -0,0 +1,38 @@
+package mailer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+)
+
+func TestClassifySendGridResponse(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantErr   bool
+		permanent bool
+	}{
+		{"accepted", 202, "", false, false},
+		{"invalid recipient", 400, `{"errors":[{"message":"Does not contain a valid address.","field":"personalizations.0.to.0.email"}]}`, true, true},
+		{"invalid request", 400, `{"errors":[{"message":"The from address does not match a verified Sender Identity.","field":"from"}]}`, true, false},
+		{"unparseable body", 400, "bad request", true, false},
+		{"revoked api key", 401, `{"errors":[{"message":"The provided authorization grant is invalid"}]}`, true, false},
+		{"forbidden", 403, `{"errors":[{"message":"access forbidden"}]}`, true, false},
+		{"rate limited", 429, "", true, false},
+		{"server error", 503, "", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifySendGridResponse(tt.status, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got := errors.Is(err, domain.ErrPermanentDelivery); got != tt.permanent {
+				t.Fatalf("permanent = %v, want %v (%v)", got, tt.permanent, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// LogMailer는 메일을 실제로 보내지 않고 로그로만 남기는 로컬 개발용 싱크입니다.
+type LogMailer struct {
+	log *zap.Logger
+}
+
+func NewLogMailer(log *zap.Logger) *LogMailer {
+	return &LogMailer{log: log}
+}
+
+func (m *LogMailer) Send(_ context.Context, to, subject, body string) error {
+	m.log.Info("mail captured by log sink", logger.Email("to", to), zap.String("subject", subject))
+	return nil
+}
+
+// FileMailer는 메일을 디렉터리에 .eml 파일로 저장하는 로컬 싱크입니다.
+// 메일 클라이언트로 열어 본문을 확인할 수 있습니다.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("file mailer: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(_ context.Context, to, subject, body string) error {
+	var b strings.Builder
+	b.WriteString("From: " + m.from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	name := time.Now().UTC().Format("20060102T150405") + "-" + uuid.New().String() + ".eml"
+	return os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o600)
+}
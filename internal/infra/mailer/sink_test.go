@@ -0,0 +1,28 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogMailerRedactsRecipient(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	m := NewLogMailer(zap.New(core))
+
+	if err := m.Send(context.Background(), "alice@example.com", "Email Verification", "code: 123456"); err != nil {
+		t.Fatal(err)
+	}
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	for key, value := range entries[0].ContextMap() {
+		if s, ok := value.(string); ok && (strings.Contains(s, "alice@example.com") || strings.Contains(s, "123456")) {
+			t.Fatalf("field %s leaks recipient or body: %q", key, s)
+		}
+	}
+}
@@ -1,24 +1,345 @@
 package mailer
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
 	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+)
+
+// TLSMode는 SMTP 서버와의 연결 암호화 방식입니다.
+type TLSMode string
+
+const (
+	TLSModeNone     TLSMode = "none"     // 평문 (로컬 개발용 SMTP 서버 전용)
+	TLSModeStartTLS TLSMode = "starttls" // 평문 연결 후 STARTTLS로 업그레이드 (보통 587 포트)
+	TLSModeImplicit TLSMode = "tls"      // 연결 시작부터 TLS (보통 465 포트)
 )
 
+// SMTPConfig는 SMTPMailer 설정입니다.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string // 비어 있으면 Username 사용
+
+	TLSMode            TLSMode
+	CAFile             string // 사설 CA를 쓰는 서버용 PEM 번들 (비어 있으면 시스템 CA)
+	ServerName         string // 인증서 검증에 사용할 호스트명 (비어 있으면 Host)
+	InsecureSkipVerify bool   // 인증서 검증 생략 (테스트 전용)
+
+	DialTimeout time.Duration // 연결 수립 타임아웃
+	SendTimeout time.Duration // ctx에 deadline이 없을 때 메시지 한 건 발송 타임아웃
+	PoolSize    int           // 재사용할 유휴 연결 최대 개수
+	IdleTimeout time.Duration // 이 시간 이상 사용하지 않은 연결은 폐기
+}
+
+// smtpConn은 풀에 보관되는 SMTP 연결입니다.
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func (c *smtpConn) close() {
+	_ = c.client.Close()
+}
+
+// quit은 QUIT을 보내고 연결을 닫습니다. 응답하지 않는 서버 때문에 종료가 멈추지 않도록 timeout 안에 끝내고,
+// QUIT이 실패하면 연결을 바로 닫습니다.
+func (c *smtpConn) quit(timeout time.Duration) {
+	_ = c.conn.SetDeadline(time.Now().Add(timeout))
+	if err := c.client.Quit(); err != nil {
+		c.close()
+	}
+}
+
+// SMTPMailer는 연결을 재사용하는 SMTP 메일러입니다.
 type SMTPMailer struct {
-	host string
-	port int
-	user string
-	pass string
+	cfg       SMTPConfig
+	tlsConfig *tls.Config
+
+	mu     sync.Mutex
+	idle   []*smtpConn
+	closed bool
+}
+
+func NewSMTPMailer(cfg SMTPConfig) (*SMTPMailer, error) {
+	if cfg.TLSMode == "" {
+		cfg.TLSMode = TLSModeStartTLS
+	}
+	if cfg.From == "" {
+		cfg.From = cfg.Username
+	}
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 10 * time.Second
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 30 * time.Second
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 2
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = 30 * time.Second
+	}
+
+	switch cfg.TLSMode {
+	case TLSModeNone, TLSModeStartTLS, TLSModeImplicit:
+	default:
+		return nil, fmt.Errorf("smtp: unknown tls mode %q", cfg.TLSMode)
+	}
+
+	serverName := cfg.ServerName
+	if serverName == "" {
+		serverName = cfg.Host
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         serverName,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("smtp: read ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("smtp: no certificates found in %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &SMTPMailer{cfg: cfg, tlsConfig: tlsConfig}, nil
 }
 
-func NewSMTPMailer(host string, port int, user, pass string) *SMTPMailer {
-	return &SMTPMailer{host: host, port: port, user: user, pass: pass}
+// Send는 메일 한 건을 발송합니다. ctx가 취소되거나 deadline이 지나면 진행 중인 통신을 중단합니다.
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.cfg.SendTimeout)
+		defer cancel()
+	}
+
+	c, err := m.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	// ctx 취소 시 블로킹된 읽기/쓰기를 즉시 깨우기 위해 deadline을 과거로 설정
+	deadline, _ := ctx.Deadline()
+	_ = c.conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		_ = c.conn.SetDeadline(time.Unix(1, 0))
+	})
+
+	err = m.send(c.client, to, m.buildMessage(to, subject, body))
+	interrupted := !stop()
+	if err != nil || interrupted {
+		c.close()
+		if err == nil {
+			err = ctx.Err()
+		}
+		if errors.Is(err, domain.ErrPermanentDelivery) {
+			return err
+		}
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	_ = c.conn.SetDeadline(time.Time{})
+	m.put(c)
+	return nil
 }
 
-func (m *SMTPMailer) Send(to, subject, body string) error {
-	auth := smtp.PlainAuth("", m.user, m.pass, m.host)
-	msg := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body))
-	addr := fmt.Sprintf("%s:%d", m.host, m.port)
-	return smtp.SendMail(addr, auth, m.user, []string{to}, msg)
+func (m *SMTPMailer) send(client *smtp.Client, to string, msg []byte) error {
+	if err := client.Mail(m.cfg.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(to); err != nil {
+		return classifyRejection(err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return classifyRejection(err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return classifyRejection(w.Close())
+}
+
+// get은 풀에서 유휴 연결을 꺼내거나 새로 연결합니다.
+func (m *SMTPMailer) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return nil, errors.New("smtp: mailer closed")
+		}
+		if len(m.idle) == 0 {
+			m.mu.Unlock()
+			break
+		}
+		c := m.idle[len(m.idle)-1]
+		m.idle = m.idle[:len(m.idle)-1]
+		m.mu.Unlock()
+
+		if time.Since(c.lastUsed) > m.cfg.IdleTimeout {
+			c.close()
+			continue
+		}
+		// 서버가 연결을 끊었는지 확인하고 이전 트랜잭션 상태를 초기화
+		_ = c.conn.SetDeadline(time.Now().Add(m.cfg.DialTimeout))
+		if err := c.client.Reset(); err != nil {
+			c.close()
+			continue
+		}
+		return c, nil
+	}
+	return m.dial(ctx)
+}
+
+// put은 사용이 끝난 연결을 풀에 반환합니다. 풀이 가득 차면 연결을 닫습니다.
+func (m *SMTPMailer) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+
+	m.mu.Lock()
+	if !m.closed && len(m.idle) < m.cfg.PoolSize {
+		m.idle = append(m.idle, c)
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+	c.quit(m.cfg.DialTimeout)
+}
+
+func (m *SMTPMailer) dial(ctx context.Context) (*smtpConn, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.cfg.DialTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("smtp: dial %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if m.cfg.TLSMode == TLSModeImplicit {
+		tlsConn := tls.Client(conn, m.tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("smtp: tls handshake: %w", err)
+		}
+		conn = tlsConn
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("smtp: greeting: %w", err)
+	}
+
+	if m.cfg.TLSMode == TLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			_ = client.Close()
+			return nil, errors.New("smtp: server does not support STARTTLS")
+		}
+		if err := client.StartTLS(m.tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, fmt.Errorf("smtp: starttls: %w", err)
+		}
+	}
+
+	if m.cfg.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+			if err := client.Auth(auth); err != nil {
+				_ = client.Close()
+				return nil, fmt.Errorf("smtp: auth: %w", err)
+			}
+		}
+	}
+
+	return &smtpConn{conn: conn, client: client, lastUsed: time.Now()}, nil
+}
+
+// Close는 풀의 모든 유휴 연결을 정리합니다.
+func (m *SMTPMailer) Close() error {
+	m.mu.Lock()
+	idle := m.idle
+	m.idle = nil
+	m.closed = true
+	m.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit(m.cfg.DialTimeout)
+	}
+	return nil
+}
+
+// buildMessage는 UTF-8 텍스트 본문을 가진 RFC 5322 메시지를 생성합니다.
+func (m *SMTPMailer) buildMessage(to, subject, body string) []byte {
+	var buf bytes.Buffer
+	header := func(k, v string) {
+		buf.WriteString(k + ": " + v + "\r\n")
+	}
+	header("From", m.cfg.From)
+	header("To", to)
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", m.messageID())
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	// 본문의 줄바꿈을 CRLF로 통일 (이미 CRLF인 줄이 CR CR LF가 되지 않도록 먼저 LF로 정규화)
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	_, _ = qp.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	_ = qp.Close()
+	return buf.Bytes()
+}
+
+func (m *SMTPMailer) messageID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	domainPart := m.cfg.Host
+	if at := strings.LastIndex(m.cfg.From, "@"); at >= 0 {
+		domainPart = m.cfg.From[at+1:]
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domainPart + ">"
+}
+
+// permanentSMTPCodes는 RCPT/DATA 단계에서 수신자나 메시지 자체를 거부하는 응답 코드입니다.
+// 530/535 같은 인증 실패와 그 밖의 5xx는 프로바이더 설정 문제일 수 있으므로 다른 프로바이더로 넘깁니다.
+var permanentSMTPCodes = map[int]bool{550: true, 551: true, 553: true, 554: true}
+
+// classifyRejection은 RCPT/DATA 단계의 수신자·메시지 거부를 재시도 불가능한 영구 실패로 분류합니다.
+func classifyRejection(err error) error {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && permanentSMTPCodes[tpErr.Code] {
+		return fmt.Errorf("%w: smtp: %d %s", domain.ErrPermanentDelivery, tpErr.Code, tpErr.Msg)
+	}
+	return err
 }
@@ -0,0 +1,383 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/pem"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/aquaheyday/go-auth-service/internal/infra/mailer/smtptest"
+)
+
+// newTLSServer는 자체 서명 인증서를 쓰는 캡처 서버와 그 인증서를 담은 CA 파일 경로를 반환합니다.
+func newTLSServer(t *testing.T, implicit bool, opts ...smtptest.Option) (*smtptest.Server, string) {
+	t.Helper()
+	tlsCfg, _, err := smtptest.SelfSignedTLS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	leaf := tlsCfg.Certificates[0].Leaf
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if implicit {
+		opts = append(opts, smtptest.WithImplicitTLS(tlsCfg))
+	} else {
+		opts = append(opts, smtptest.WithStartTLS(tlsCfg))
+	}
+	return newServer(t, opts...), caFile
+}
+
+func newServer(t *testing.T, opts ...smtptest.Option) *smtptest.Server {
+	t.Helper()
+	srv, err := smtptest.NewServer(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = srv.Close() })
+	return srv
+}
+
+func newMailer(t *testing.T, cfg SMTPConfig) *SMTPMailer {
+	t.Helper()
+	if cfg.From == "" {
+		cfg.From = "noreply@example.com"
+	}
+	m, err := NewSMTPMailer(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = m.Close() })
+	return m
+}
+
+func waitForMessage(t *testing.T, srv *smtptest.Server) smtptest.Message {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	msgs, err := srv.WaitForMessages(ctx, 1)
+	if err != nil {
+		t.Fatalf("wait for message: %v", err)
+	}
+	return msgs[0]
+}
+
+func TestSMTPMailerTLSModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     TLSMode
+		implicit bool
+	}{
+		{"starttls", TLSModeStartTLS, false},
+		{"implicit tls", TLSModeImplicit, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, caFile := newTLSServer(t, tt.implicit)
+			m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: tt.mode, CAFile: caFile})
+
+			if err := m.Send(context.Background(), "user@example.com", "인증 코드", "코드: 123456"); err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			msg := waitForMessage(t, srv)
+			if !msg.TLS {
+				t.Fatal("message was delivered over a plaintext connection")
+			}
+			if msg.From != "noreply@example.com" || len(msg.To) != 1 || msg.To[0] != "user@example.com" {
+				t.Fatalf("envelope = %q -> %v", msg.From, msg.To)
+			}
+			if got := msg.Subject(); got != "인증 코드" {
+				t.Fatalf("subject = %q", got)
+			}
+		})
+	}
+}
+
+func TestSMTPMailerStartTLSRejectsUntrustedCertificate(t *testing.T) {
+	srv, _ := newTLSServer(t, false)
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeStartTLS})
+
+	if err := m.Send(context.Background(), "user@example.com", "s", "b"); err == nil {
+		t.Fatal("Send succeeded with an untrusted server certificate")
+	}
+	if n := len(srv.Messages()); n != 0 {
+		t.Fatalf("server received %d messages", n)
+	}
+}
+
+func TestSMTPMailerStartTLSRequiredButUnsupported(t *testing.T) {
+	srv := newServer(t)
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeStartTLS})
+
+	err := m.Send(context.Background(), "user@example.com", "s", "b")
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("Send = %v, want STARTTLS error", err)
+	}
+}
+
+func TestSMTPMailerAuth(t *testing.T) {
+	srv, caFile := newTLSServer(t, false, smtptest.WithAuth("mailer", "s3cret"))
+
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeStartTLS, CAFile: caFile,
+		Username: "mailer", Password: "s3cret"})
+	if err := m.Send(context.Background(), "user@example.com", "s", "b"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if msg := waitForMessage(t, srv); msg.Auth != "mailer" {
+		t.Fatalf("auth user = %q, want mailer", msg.Auth)
+	}
+
+	wrong := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeStartTLS, CAFile: caFile,
+		Username: "mailer", Password: "wrong"})
+	err := wrong.Send(context.Background(), "user@example.com", "s", "b")
+	if err == nil {
+		t.Fatal("Send succeeded with a wrong password")
+	}
+	// 인증 실패는 프로바이더 설정 문제이므로 다른 프로바이더로 넘길 수 있어야 함
+	if errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("auth failure classified as permanent: %v", err)
+	}
+	if n := len(srv.Messages()); n != 1 {
+		t.Fatalf("server received %d messages, want 1", n)
+	}
+}
+
+func TestSMTPMailerReusesPooledConnection(t *testing.T) {
+	srv := newServer(t)
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeNone, PoolSize: 1})
+	ctx := context.Background()
+
+	if err := m.Send(ctx, "a@example.com", "first", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.idle) != 1 {
+		t.Fatalf("idle connections = %d, want 1", len(m.idle))
+	}
+	pooled := m.idle[0].conn
+
+	srv.Reset()
+	if err := m.Send(ctx, "b@example.com", "second", "b"); err != nil {
+		t.Fatalf("Send on pooled connection: %v", err)
+	}
+	if len(m.idle) != 1 || m.idle[0].conn != pooled {
+		t.Fatal("second message did not reuse the pooled connection")
+	}
+
+	// RSET으로 이전 트랜잭션이 초기화되어 수신자가 섞이지 않음
+	msg := waitForMessage(t, srv)
+	if len(msg.To) != 1 || msg.To[0] != "b@example.com" || msg.Subject() != "second" {
+		t.Fatalf("message on reused connection = %v %q", msg.To, msg.Subject())
+	}
+	if n := len(srv.Messages()); n != 1 {
+		t.Fatalf("server holds %d messages after Reset, want 1", n)
+	}
+}
+
+func TestSMTPMailerRedialsWhenPooledConnectionIsClosed(t *testing.T) {
+	srv := newServer(t)
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeNone})
+	ctx := context.Background()
+
+	if err := m.Send(ctx, "a@example.com", "s", "b"); err != nil {
+		t.Fatal(err)
+	}
+	// 서버 쪽에서 유휴 연결이 끊긴 상황
+	_ = m.idle[0].conn.Close()
+
+	if err := m.Send(ctx, "a@example.com", "s", "b"); err != nil {
+		t.Fatalf("Send after pooled connection closed: %v", err)
+	}
+	if n := len(srv.Messages()); n != 2 {
+		t.Fatalf("server received %d messages, want 2", n)
+	}
+}
+
+func TestSMTPMailerPermanentFailure(t *testing.T) {
+	srv := newServer(t, smtptest.WithRejectRecipients("gone@example.com"))
+	m := newMailer(t, SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: TLSModeNone})
+
+	err := m.Send(context.Background(), "gone@example.com", "s", "b")
+	if !errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("Send = %v, want ErrPermanentDelivery", err)
+	}
+	if len(m.idle) != 0 {
+		t.Fatal("connection with a failed transaction returned to the pool")
+	}
+
+	// 다른 수신자는 정상 발송
+	if err := m.Send(context.Background(), "user@example.com", "s", "b"); err != nil {
+		t.Fatalf("Send to accepted recipient: %v", err)
+	}
+}
+
+func TestSMTPMailerTransientFailureIsRetryable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close() // 아무도 대기하지 않는 포트
+
+	m := newMailer(t, SMTPConfig{Host: "127.0.0.1", Port: port, TLSMode: TLSModeNone, DialTimeout: time.Second})
+	err = m.Send(context.Background(), "user@example.com", "s", "b")
+	if err == nil || errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("Send = %v, want retryable error", err)
+	}
+}
+
+// stalledServer는 인사말만 보내고 이후 명령에 응답하지 않는 서버의 포트를 반환합니다.
+func stalledServer(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+			go func() {
+				_, _ = conn.Write([]byte("220 stalled ESMTP\r\n"))
+				_, _ = bufio.NewReader(conn).ReadString(0) // 응답하지 않고 연결이 닫힐 때까지 대기
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestSMTPMailerContextCancelInterruptsSend(t *testing.T) {
+	port := stalledServer(t)
+	m := newMailer(t, SMTPConfig{Host: "127.0.0.1", Port: port, TLSMode: TLSModeNone, SendTimeout: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := m.Send(ctx, "user@example.com", "s", "b")
+	if err == nil {
+		t.Fatal("Send succeeded against a stalled server")
+	}
+	if errors.Is(err, domain.ErrPermanentDelivery) {
+		t.Fatalf("interrupted send classified as permanent: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Send returned after %v, cancel did not interrupt it", elapsed)
+	}
+	if len(m.idle) != 0 {
+		t.Fatal("interrupted connection returned to the pool")
+	}
+}
+
+func TestSMTPMailerDeadlineInterruptsSend(t *testing.T) {
+	port := stalledServer(t)
+	m := newMailer(t, SMTPConfig{Host: "127.0.0.1", Port: port, TLSMode: TLSModeNone})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := m.Send(ctx, "user@example.com", "s", "b"); err == nil {
+		t.Fatal("Send succeeded against a stalled server")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Send returned after %v, deadline was not applied", elapsed)
+	}
+}
+
+func TestSMTPMailerNormalizesLineEndings(t *testing.T) {
+	m := newMailer(t, SMTPConfig{Host: "smtp.example.com", Port: 25, TLSMode: TLSModeNone})
+
+	msg := string(m.buildMessage("user@example.com", "s", "line1\r\nline2\nline3"))
+	_, body, _ := strings.Cut(msg, "\r\n\r\n")
+	if body != "line1\r\nline2\r\nline3" {
+		t.Fatalf("body = %q, want CRLF line endings", body)
+	}
+	if strings.Contains(msg, "\r\r\n") || strings.Contains(msg, "=0D") {
+		t.Fatalf("message contains a doubled CR: %q", msg)
+	}
+}
+
+// quitStallingServer는 메일 발송에는 응답하지만 QUIT에는 응답하지 않는 서버의 포트를 반환합니다.
+func quitStallingServer(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+			go func() {
+				r := bufio.NewReader(conn)
+				_, _ = conn.Write([]byte("220 ready ESMTP\r\n"))
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+					case strings.HasPrefix(cmd, "QUIT"):
+						_, _ = r.ReadString(0) // 응답하지 않고 연결이 닫힐 때까지 대기
+						return
+					default:
+						_, _ = conn.Write([]byte("250 ok\r\n"))
+					}
+				}
+			}()
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func TestSMTPMailerQuitDoesNotHang(t *testing.T) {
+	port := quitStallingServer(t)
+	m := newMailer(t, SMTPConfig{Host: "127.0.0.1", Port: port, TLSMode: TLSModeNone, PoolSize: 1, DialTimeout: 100 * time.Millisecond})
+	ctx := context.Background()
+
+	first, err := m.dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := m.dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []*smtpConn{first, second} {
+		_ = c.conn.SetDeadline(time.Time{})
+	}
+
+	// 풀이 가득 차 반환되지 못한 연결은 QUIT 후 닫힘
+	m.put(first)
+	start := time.Now()
+	m.put(second)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("put waited %v for QUIT", elapsed)
+	}
+
+	// 풀에 남은 연결은 Close에서 정리
+	start = time.Now()
+	if err := m.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Close waited %v for QUIT", elapsed)
+	}
+}
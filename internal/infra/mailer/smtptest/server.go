@@ -0,0 +1,422 @@
+// Package smtptest는 테스트에서 실제 SMTP 통신을 검증할 수 있도록
+// 프로세스 내부에서 동작하는 SMTP 캡처 서버를 제공합니다.
+//
+// 서버는 받은 메시지를 메모리에 보관하며 외부로 전달하지 않습니다.
+//
+//	srv, _ := smtptest.NewServer()
+//	defer srv.Close()
+//	m, _ := mailer.NewSMTPMailer(mailer.SMTPConfig{Host: srv.Host(), Port: srv.Port(), TLSMode: mailer.TLSModeNone})
+//	_ = m.Send(ctx, "user@example.com", "hi", "body")
+//	msgs, _ := srv.WaitForMessages(ctx, 1)
+package smtptest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Message는 서버가 수신한 메일 한 건입니다.
+type Message struct {
+	From string   // MAIL FROM 주소
+	To   []string // RCPT TO 주소 목록
+	Data []byte   // DATA로 전달된 원본 메시지 (헤더 포함)
+	TLS  bool     // TLS 연결로 수신했는지 여부
+	Auth string   // 인증에 사용된 사용자명 (인증하지 않았으면 빈 문자열)
+}
+
+// Header는 원본 메시지의 헤더를 파싱해 반환합니다.
+func (m Message) Header() mail.Header {
+	msg, err := mail.ReadMessage(bytes.NewReader(m.Data))
+	if err != nil {
+		return mail.Header{}
+	}
+	return msg.Header
+}
+
+// Subject는 디코딩된 제목을 반환합니다.
+func (m Message) Subject() string {
+	subject := m.Header().Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+		return decoded
+	}
+	return subject
+}
+
+// Option은 서버 동작을 설정합니다.
+type Option func(*Server)
+
+// WithStartTLS는 STARTTLS 확장을 활성화합니다.
+func WithStartTLS(cfg *tls.Config) Option {
+	return func(s *Server) { s.tlsConfig = cfg }
+}
+
+// WithImplicitTLS는 연결 시작부터 TLS로 통신하도록 설정합니다 (SMTPS).
+func WithImplicitTLS(cfg *tls.Config) Option {
+	return func(s *Server) {
+		s.tlsConfig = cfg
+		s.implicitTLS = true
+	}
+}
+
+// WithAuth는 AUTH PLAIN을 요구하도록 설정합니다.
+func WithAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username = username
+		s.password = password
+	}
+}
+
+// WithRejectRecipients는 지정한 수신자에 대해 550 응답을 반환하도록 설정합니다.
+func WithRejectRecipients(addrs ...string) Option {
+	return func(s *Server) {
+		for _, a := range addrs {
+			s.rejected[strings.ToLower(a)] = true
+		}
+	}
+}
+
+// Server는 프로세스 내부 SMTP 캡처 서버입니다.
+type Server struct {
+	ln          net.Listener
+	tlsConfig   *tls.Config
+	implicitTLS bool
+	username    string
+	password    string
+	rejected    map[string]bool
+
+	mu       sync.Mutex
+	messages []Message
+	notify   chan struct{}
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// NewServer는 127.0.0.1의 임의 포트에서 대기하는 서버를 시작합니다.
+func NewServer(opts ...Option) (*Server, error) {
+	s := &Server{
+		rejected: make(map[string]bool),
+		notify:   make(chan struct{}),
+		conns:    make(map[net.Conn]struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	if s.implicitTLS {
+		ln = tls.NewListener(ln, s.tlsConfig)
+	}
+	s.ln = ln
+
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr는 host:port 형식의 서버 주소를 반환합니다.
+func (s *Server) Addr() string { return s.ln.Addr().String() }
+
+// Host는 서버 호스트를 반환합니다.
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Addr())
+	return host
+}
+
+// Port는 서버 포트를 반환합니다.
+func (s *Server) Port() int {
+	_, port, _ := net.SplitHostPort(s.Addr())
+	n, _ := strconv.Atoi(port)
+	return n
+}
+
+// Messages는 지금까지 수신한 메시지의 복사본을 반환합니다.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// Reset은 수신한 메시지를 모두 삭제합니다.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	s.messages = nil
+	s.mu.Unlock()
+}
+
+// WaitForMessages는 n 건 이상의 메시지가 수신될 때까지 기다립니다.
+func (s *Server) WaitForMessages(ctx context.Context, n int) ([]Message, error) {
+	for {
+		s.mu.Lock()
+		if len(s.messages) >= n {
+			msgs := append([]Message(nil), s.messages...)
+			s.mu.Unlock()
+			return msgs, nil
+		}
+		notify := s.notify
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return s.Messages(), ctx.Err()
+		case <-notify:
+		}
+	}
+}
+
+// Close는 리스너와 열린 연결을 모두 닫고 처리 중인 고루틴이 끝날 때까지 기다립니다.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for c := range s.conns {
+		_ = c.Close()
+	}
+	s.mu.Unlock()
+
+	err := s.ln.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *Server) deliver(msg Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, msg)
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+}
+
+// session은 연결 하나의 SMTP 트랜잭션 상태입니다.
+type session struct {
+	conn  net.Conn
+	tp    *textproto.Conn
+	tls   bool
+	auth  string
+	from  string
+	to    []string
+	greet bool
+}
+
+func (sess *session) reply(code int, msg string) error {
+	return sess.tp.PrintfLine("%d %s", code, msg)
+}
+
+func (sess *session) reset() {
+	sess.from = ""
+	sess.to = nil
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	_, isTLS := conn.(*tls.Conn)
+	sess := &session{conn: conn, tp: textproto.NewConn(conn), tls: isTLS}
+	if err := sess.reply(220, "smtptest ESMTP ready"); err != nil {
+		return
+	}
+
+	for {
+		line, err := sess.tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		verb = strings.ToUpper(verb)
+
+		switch verb {
+		case "HELO":
+			sess.greet = true
+			sess.reset()
+			err = sess.reply(250, "smtptest")
+		case "EHLO":
+			sess.greet = true
+			sess.reset()
+			err = s.ehlo(sess)
+		case "STARTTLS":
+			if s.tlsConfig == nil || sess.tls {
+				err = sess.reply(502, "STARTTLS not available")
+				break
+			}
+			if err = sess.reply(220, "ready to start TLS"); err != nil {
+				return
+			}
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			// TLS 업그레이드 후에는 세션 상태를 처음부터 다시 시작 (RFC 3207)
+			sess = &session{conn: tlsConn, tp: textproto.NewConn(tlsConn), tls: true}
+		case "AUTH":
+			err = s.authenticate(sess, arg)
+		case "MAIL":
+			switch {
+			case !sess.greet:
+				err = sess.reply(503, "send EHLO first")
+			case s.username != "" && sess.auth == "":
+				err = sess.reply(530, "authentication required")
+			default:
+				sess.from = parsePath(arg, "FROM:")
+				err = sess.reply(250, "OK")
+			}
+		case "RCPT":
+			addr := parsePath(arg, "TO:")
+			switch {
+			case sess.from == "":
+				err = sess.reply(503, "need MAIL first")
+			case s.rejected[strings.ToLower(addr)]:
+				err = sess.reply(550, "mailbox unavailable")
+			default:
+				sess.to = append(sess.to, addr)
+				err = sess.reply(250, "OK")
+			}
+		case "DATA":
+			if len(sess.to) == 0 {
+				err = sess.reply(503, "need RCPT first")
+				break
+			}
+			if err = sess.reply(354, "end data with <CR><LF>.<CR><LF>"); err != nil {
+				return
+			}
+			data, rerr := io.ReadAll(sess.tp.DotReader())
+			if rerr != nil {
+				return
+			}
+			s.deliver(Message{From: sess.from, To: sess.to, Data: data, TLS: sess.tls, Auth: sess.auth})
+			sess.reset()
+			err = sess.reply(250, "OK: queued")
+		case "RSET":
+			sess.reset()
+			err = sess.reply(250, "OK")
+		case "NOOP":
+			err = sess.reply(250, "OK")
+		case "QUIT":
+			_ = sess.reply(221, "bye")
+			return
+		default:
+			err = sess.reply(502, "command not implemented")
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) ehlo(sess *session) error {
+	lines := []string{"smtptest", "8BITMIME", "PIPELINING"}
+	if s.tlsConfig != nil && !sess.tls {
+		lines = append(lines, "STARTTLS")
+	}
+	if s.username != "" {
+		lines = append(lines, "AUTH PLAIN")
+	}
+	for i, l := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		if err := sess.tp.PrintfLine("250%s%s", sep, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authenticate는 AUTH PLAIN을 처리합니다.
+func (s *Server) authenticate(sess *session, arg string) error {
+	mech, initial, _ := strings.Cut(arg, " ")
+	if s.username == "" || !strings.EqualFold(mech, "PLAIN") {
+		return sess.reply(504, "unrecognized authentication type")
+	}
+	if sess.auth != "" {
+		return sess.reply(503, "already authenticated")
+	}
+
+	if initial == "" {
+		if err := sess.tp.PrintfLine("334 "); err != nil {
+			return err
+		}
+		line, err := sess.tp.ReadLine()
+		if err != nil {
+			return err
+		}
+		initial = line
+	}
+
+	user, pass, err := decodePlain(initial)
+	if err != nil {
+		return sess.reply(501, "malformed credentials")
+	}
+	if user != s.username || pass != s.password {
+		return sess.reply(535, "authentication failed")
+	}
+	sess.auth = user
+	return sess.reply(235, "authentication successful")
+}
+
+// decodePlain은 "authzid\x00user\x00pass" 형식의 AUTH PLAIN 자격 증명을 해석합니다.
+func decodePlain(encoded string) (string, string, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", err
+	}
+	parts := bytes.Split(raw, []byte{0})
+	if len(parts) != 3 {
+		return "", "", errors.New("invalid PLAIN payload")
+	}
+	return string(parts[1]), string(parts[2]), nil
+}
+
+// parsePath는 "FROM:<addr> SIZE=..." 형식에서 주소만 추출합니다.
+func parsePath(arg, prefix string) string {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return ""
+	}
+	path := strings.TrimSpace(arg[len(prefix):])
+	if i := strings.IndexByte(path, ' '); i >= 0 {
+		path = path[:i]
+	}
+	return strings.Trim(path, "<>")
+}
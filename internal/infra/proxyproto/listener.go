@@ -0,0 +1,287 @@
+// internal/infra/proxyproto/listener.go
+// 이 파일은 로드밸런서가 보낸 PROXY protocol(v1, v2) 헤더에서 원래 클라이언트 주소를 복원하는 리스너를 정의합니다.
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Mode는 신뢰하는 프록시에서 온 연결에 PROXY 헤더를 요구하는 방식입니다.
+type Mode string
+
+const (
+	ModeOff      Mode = "off"      // 헤더를 해석하지 않음
+	ModeOptional Mode = "optional" // 헤더가 있으면 해석, 없으면 연결 주소 사용
+	ModeRequire  Mode = "require"  // 헤더가 없으면 연결 종료
+)
+
+const (
+	defaultHeaderTimeout = 5 * time.Second
+	v1MaxLength          = 107 // "PROXY TCP6 <39> <39> <5> <5>\r\n"
+)
+
+var (
+	v1Prefix    = []byte("PROXY ")
+	v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	errMissingHeader = errors.New("proxyproto: missing PROXY protocol header")
+)
+
+var headersTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Name: "proxy_protocol_headers_total",
+	Help: "Number of connections from trusted proxies by PROXY protocol header result (ok, local, missing, invalid)",
+}, []string{"result"})
+
+// Config는 PROXY protocol 리스너 설정입니다.
+type Config struct {
+	Mode          Mode
+	Trusted       []netip.Prefix // PROXY 헤더를 보낼 수 있는 프록시 주소 (그 외 연결의 헤더는 해석하지 않음)
+	HeaderTimeout time.Duration  // 헤더 수신 대기 시간 (기본 5초)
+}
+
+// NewListener는 inner를 감싸 신뢰하는 프록시에서 온 연결의 RemoteAddr를 PROXY 헤더의 원래 클라이언트 주소로 바꿉니다.
+// ModeOff이면 inner를 그대로 반환합니다.
+func NewListener(inner net.Listener, cfg Config) (net.Listener, error) {
+	switch cfg.Mode {
+	case "", ModeOff:
+		return inner, nil
+	case ModeOptional, ModeRequire:
+	default:
+		return nil, fmt.Errorf("proxyproto: unknown mode %q", cfg.Mode)
+	}
+	if len(cfg.Trusted) == 0 {
+		return nil, errors.New("proxyproto: trusted proxy addresses are required")
+	}
+	if cfg.HeaderTimeout <= 0 {
+		cfg.HeaderTimeout = defaultHeaderTimeout
+	}
+	return &listener{Listener: inner, cfg: cfg}, nil
+}
+
+type listener struct {
+	net.Listener
+	cfg Config
+}
+
+// Accept는 헤더를 기다리지 않고 바로 반환합니다.
+// 헤더는 연결별 고루틴에서 처음 Read/RemoteAddr를 호출할 때 읽으므로 느린 연결이 Accept를 막지 않습니다.
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !trusted(c.RemoteAddr(), l.cfg.Trusted) {
+		return c, nil
+	}
+	return &conn{Conn: c, reader: bufio.NewReaderSize(c, 256), cfg: l.cfg}, nil
+}
+
+// ParsePrefixes는 CIDR 또는 IP 주소 목록을 해석합니다 (IP 주소는 단일 주소 대역으로 취급).
+func ParsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			p, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q: %w", v, err)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address %q: %w", v, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Contains는 addr가 prefixes 중 하나에 속하는지 확인합니다.
+func Contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func trusted(addr net.Addr, prefixes []netip.Prefix) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	ip, ok := netip.AddrFromSlice(tcp.IP)
+	return ok && Contains(prefixes, ip)
+}
+
+// conn은 PROXY 헤더를 읽은 뒤 나머지 바이트를 그대로 전달하는 연결입니다.
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+	cfg    Config
+
+	once   sync.Once
+	remote net.Addr
+	err    error
+}
+
+func (c *conn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+func (c *conn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.remote != nil {
+		return c.remote
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// readHeader는 연결 앞부분의 PROXY 헤더를 읽고 원래 클라이언트 주소를 기록합니다.
+// 실패하면 이후 Read가 에러를 반환하므로 서버가 연결을 닫습니다.
+func (c *conn) readHeader() {
+	_ = c.Conn.SetReadDeadline(time.Now().Add(c.cfg.HeaderTimeout))
+	defer c.Conn.SetReadDeadline(time.Time{})
+
+	remote, err := c.parse()
+	switch {
+	case errors.Is(err, errMissingHeader) && c.cfg.Mode == ModeOptional:
+		headersTotal.WithLabelValues("missing").Inc()
+	case errors.Is(err, errMissingHeader):
+		headersTotal.WithLabelValues("missing").Inc()
+		c.err = err
+	case err != nil:
+		headersTotal.WithLabelValues("invalid").Inc()
+		c.err = err
+	case remote == nil:
+		headersTotal.WithLabelValues("local").Inc() // 프록시 자체의 헬스 체크 등
+	default:
+		headersTotal.WithLabelValues("ok").Inc()
+		c.remote = remote
+	}
+}
+
+// parse는 v1 또는 v2 헤더를 읽습니다. 주소가 없는 헤더(LOCAL, UNKNOWN)이면 nil 주소를 반환합니다.
+func (c *conn) parse() (net.Addr, error) {
+	// 첫 바이트로 형식 판별 (v1 'P', v2 '\r'). 헤더보다 짧은 요청도 있으므로 필요한 만큼만 Peek
+	first, err := c.reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	switch first[0] {
+	case v1Prefix[0]:
+		if b, err := c.reader.Peek(len(v1Prefix)); err == nil && bytes.Equal(b, v1Prefix) {
+			return c.parseV1()
+		}
+	case v2Signature[0]:
+		if b, err := c.reader.Peek(len(v2Signature)); err == nil && bytes.Equal(b, v2Signature) {
+			return c.parseV2()
+		}
+	}
+	return nil, errMissingHeader
+}
+
+// parseV1은 "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n" 형식의 텍스트 헤더를 읽습니다.
+func (c *conn) parseV1() (net.Addr, error) {
+	var line []byte
+	for len(line) < v1MaxLength {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.New("proxyproto: v1 header too long or not terminated")
+	}
+
+	fields := strings.Fields(string(line[:len(line)-2]))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("proxyproto: malformed v1 header %q", line)
+	}
+	ip, err := netip.ParseAddr(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("proxyproto: invalid v1 source address: %w", err)
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("proxyproto: invalid v1 source port: %w", err)
+	}
+	return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip, uint16(port))), nil
+}
+
+// parseV2는 바이너리 헤더를 읽습니다. TLV 확장은 읽고 무시합니다.
+func (c *conn) parseV2() (net.Addr, error) {
+	var hdr [16]byte
+	if _, err := io.ReadFull(c.reader, hdr[:]); err != nil {
+		return nil, err
+	}
+	version, command := hdr[12]>>4, hdr[12]&0x0f
+	if version != 2 {
+		return nil, fmt.Errorf("proxyproto: unsupported v2 version %d", version)
+	}
+	family := hdr[13]
+	length := int(binary.BigEndian.Uint16(hdr[14:16]))
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, err
+	}
+
+	switch command {
+	case 0x0: // LOCAL
+		return nil, nil
+	case 0x1: // PROXY
+	default:
+		return nil, fmt.Errorf("proxyproto: unsupported v2 command %d", command)
+	}
+
+	switch family {
+	case 0x11: // TCP over IPv4
+		if length < 12 {
+			return nil, errors.New("proxyproto: short v2 IPv4 address block")
+		}
+		ip := netip.AddrFrom4([4]byte(payload[0:4]))
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip, binary.BigEndian.Uint16(payload[8:10]))), nil
+	case 0x21: // TCP over IPv6
+		if length < 36 {
+			return nil, errors.New("proxyproto: short v2 IPv6 address block")
+		}
+		ip := netip.AddrFrom16([16]byte(payload[0:16]))
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(ip, binary.BigEndian.Uint16(payload[32:34]))), nil
+	default:
+		return nil, nil // UNSPEC, UDP, UNIX 등은 연결 주소를 그대로 사용
+	}
+}
@@ -0,0 +1,201 @@
+package proxyproto
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newPipeConn은 data를 보낸 뒤 닫히는 신뢰하는 프록시 연결을 만듭니다.
+func newPipeConn(t *testing.T, mode Mode, data []byte) *conn {
+	t.Helper()
+	server, client := net.Pipe()
+	t.Cleanup(func() { _ = server.Close() })
+	go func() {
+		_, _ = client.Write(data)
+		_ = client.Close()
+	}()
+	return &conn{Conn: server, reader: bufio.NewReaderSize(server, 256), cfg: Config{Mode: mode, HeaderTimeout: time.Second}}
+}
+
+// v2Header는 command, family와 주소 블록으로 v2 헤더를 만듭니다.
+func v2Header(version, command, family byte, addrs []byte) []byte {
+	hdr := append([]byte{}, v2Signature...)
+	hdr = append(hdr, version<<4|command, family)
+	hdr = binary.BigEndian.AppendUint16(hdr, uint16(len(addrs)))
+	return append(hdr, addrs...)
+}
+
+func v2IPv4(src, dst string, srcPort, dstPort uint16) []byte {
+	s, d := netip.MustParseAddr(src).As4(), netip.MustParseAddr(dst).As4()
+	b := append(s[:], d[:]...)
+	b = binary.BigEndian.AppendUint16(b, srcPort)
+	return binary.BigEndian.AppendUint16(b, dstPort)
+}
+
+func v2IPv6(src, dst string, srcPort, dstPort uint16) []byte {
+	s, d := netip.MustParseAddr(src).As16(), netip.MustParseAddr(dst).As16()
+	b := append(s[:], d[:]...)
+	b = binary.BigEndian.AppendUint16(b, srcPort)
+	return binary.BigEndian.AppendUint16(b, dstPort)
+}
+
+func TestConnParsesHeader(t *testing.T) {
+	const body = "PRI * HTTP/2.0\r\n"
+	tests := []struct {
+		name    string
+		mode    Mode
+		header  []byte
+		remote  string // 비어 있으면 연결 주소 유지
+		wantErr bool
+	}{
+		{"v1 tcp4", ModeRequire, []byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"), "192.0.2.1:56324", false},
+		{"v1 tcp6", ModeRequire, []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"), "[2001:db8::1]:56324", false},
+		{"v1 unknown", ModeRequire, []byte("PROXY UNKNOWN\r\n"), "", false},
+		{"v1 too long", ModeRequire, []byte("PROXY TCP4 " + strings.Repeat("1", 120) + "\r\n"), "", true},
+		{"v1 without crlf", ModeRequire, []byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\n"), "", true},
+		{"v1 bad address", ModeRequire, []byte("PROXY TCP4 192.0.2.999 198.51.100.1 56324 443\r\n"), "", true},
+		{"v1 bad port", ModeRequire, []byte("PROXY TCP4 192.0.2.1 198.51.100.1 99999 443\r\n"), "", true},
+		{"v1 wrong field count", ModeRequire, []byte("PROXY TCP4 192.0.2.1 56324\r\n"), "", true},
+		{"v2 proxy ipv4", ModeRequire, v2Header(2, 1, 0x11, v2IPv4("192.0.2.1", "198.51.100.1", 56324, 443)), "192.0.2.1:56324", false},
+		{"v2 proxy ipv6", ModeRequire, v2Header(2, 1, 0x21, v2IPv6("2001:db8::1", "2001:db8::2", 56324, 443)), "[2001:db8::1]:56324", false},
+		{"v2 with tlv", ModeRequire, v2Header(2, 1, 0x11, append(v2IPv4("192.0.2.1", "198.51.100.1", 56324, 443), 0x04, 0x00, 0x01, 0xff)), "192.0.2.1:56324", false},
+		{"v2 local", ModeRequire, v2Header(2, 0, 0x00, nil), "", false},
+		{"v2 unknown family", ModeRequire, v2Header(2, 1, 0x31, make([]byte, 216)), "", false},
+		{"v2 short ipv4 block", ModeRequire, v2Header(2, 1, 0x11, make([]byte, 8)), "", true},
+		{"v2 short ipv6 block", ModeRequire, v2Header(2, 1, 0x21, make([]byte, 12)), "", true},
+		{"v2 bad version", ModeRequire, v2Header(1, 1, 0x11, v2IPv4("192.0.2.1", "198.51.100.1", 1, 2)), "", true},
+		{"v2 bad command", ModeRequire, v2Header(2, 2, 0x11, v2IPv4("192.0.2.1", "198.51.100.1", 1, 2)), "", true},
+		{"missing header optional", ModeOptional, nil, "", false},
+		{"missing header required", ModeRequire, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newPipeConn(t, tt.mode, append(tt.header, body...))
+			original := c.Conn.RemoteAddr().String()
+
+			remote := c.RemoteAddr().String()
+			want := tt.remote
+			if want == "" {
+				want = original
+			}
+			if remote != want {
+				t.Fatalf("RemoteAddr = %s, want %s", remote, want)
+			}
+
+			got, err := io.ReadAll(c)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Read succeeded after an invalid header, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read: %v", err)
+			}
+			// 헤더 뒤의 바이트는 그대로 전달
+			if string(got) != body {
+				t.Fatalf("payload = %q, want %q", got, body)
+			}
+		})
+	}
+}
+
+func TestConnHeaderTimeout(t *testing.T) {
+	server, client := net.Pipe()
+	t.Cleanup(func() { _ = server.Close(); _ = client.Close() })
+	c := &conn{Conn: server, reader: bufio.NewReaderSize(server, 256), cfg: Config{Mode: ModeRequire, HeaderTimeout: 50 * time.Millisecond}}
+
+	start := time.Now()
+	if _, err := c.Read(make([]byte, 1)); err == nil {
+		t.Fatal("Read succeeded without a header")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("header read took %v, timeout not applied", elapsed)
+	}
+}
+
+// dialAndSend는 l에 연결해 data를 보내고, 서버 쪽 연결의 RemoteAddr와 수신 데이터를 반환합니다.
+func dialAndSend(t *testing.T, l net.Listener, data string) (string, string) {
+	t.Helper()
+	go func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			return
+		}
+		_, _ = c.Write([]byte(data))
+		_ = c.Close()
+	}()
+	c, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	got, err := io.ReadAll(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.RemoteAddr().String(), string(got)
+}
+
+func TestListenerOnlyTrustsConfiguredProxies(t *testing.T) {
+	const header = "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n"
+	tests := []struct {
+		name       string
+		trusted    string
+		wantRemote string
+		wantData   string
+	}{
+		{"trusted proxy", "127.0.0.1", "192.0.2.1:56324", "hello"},
+		// 신뢰하지 않는 연결은 헤더를 해석하지 않고 그대로 전달
+		{"untrusted connection", "203.0.113.0/24", "127.0.0.1:", header + "hello"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = inner.Close() })
+			trusted, err := ParsePrefixes([]string{tt.trusted})
+			if err != nil {
+				t.Fatal(err)
+			}
+			l, err := NewListener(inner, Config{Mode: ModeRequire, Trusted: trusted})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			remote, data := dialAndSend(t, l, header+"hello")
+			if !strings.HasPrefix(remote, tt.wantRemote) {
+				t.Fatalf("RemoteAddr = %s, want %s", remote, tt.wantRemote)
+			}
+			if data != tt.wantData {
+				t.Fatalf("data = %q, want %q", data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestNewListenerConfig(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inner.Close()
+
+	if l, err := NewListener(inner, Config{Mode: ModeOff}); err != nil || l != inner {
+		t.Fatalf("ModeOff = %v, %v; want the inner listener", l, err)
+	}
+	if _, err := NewListener(inner, Config{Mode: ModeRequire}); err == nil {
+		t.Fatal("NewListener accepted require mode without trusted proxies")
+	}
+	if _, err := NewListener(inner, Config{Mode: "sometimes", Trusted: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}}); err == nil {
+		t.Fatal("NewListener accepted an unknown mode")
+	}
+}
@@ -0,0 +1,55 @@
+// internal/infra/ratelimit/fallback.go
+// 이 파일은 공유 저장소 장애 시 메모리 Limiter로 대신 제한하는 Fallback을 정의합니다.
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// fallbackCooldown은 primary 실패 후 다시 시도하기 전까지 secondary만 사용하는 시간입니다.
+// Redis가 내려간 동안 모든 요청이 타임아웃을 기다리지 않도록 합니다.
+const fallbackCooldown = 5 * time.Second
+
+var fallbackRequests = metrics.Factory.NewCounter(prometheus.CounterOpts{
+	Name: "rate_limiter_fallback_total",
+	Help: "Number of rate limit checks served by the local fallback because the shared backend was unavailable",
+})
+
+// Fallback은 primary가 실패하면 secondary로 제한하는 Limiter입니다.
+// 장애 동안에는 레플리카별로 따로 계산되므로 실제 허용량이 늘어날 수 있지만, 제한 자체는 유지됩니다.
+type Fallback struct {
+	primary   Limiter
+	secondary Limiter
+	log       *zap.Logger
+	downUntil atomic.Int64 // primary를 다시 시도할 시각 (UnixNano, 0이면 정상)
+}
+
+func NewFallback(primary, secondary Limiter, log *zap.Logger) *Fallback {
+	return &Fallback{primary: primary, secondary: secondary, log: log}
+}
+
+func (f *Fallback) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if until := f.downUntil.Load(); until == 0 || time.Now().UnixNano() >= until {
+		res, err := f.primary.Allow(ctx, key, limit)
+		if err == nil {
+			if until != 0 && f.downUntil.CompareAndSwap(until, 0) {
+				f.log.Info("rate limiter backend recovered")
+			}
+			return res, nil
+		}
+		// 상태가 바뀔 때만 기록 (요청마다 에러 로그가 쌓이지 않도록)
+		if f.downUntil.Swap(time.Now().Add(fallbackCooldown).UnixNano()) == 0 {
+			logger.Ctx(ctx, f.log).Warn("rate limiter backend unavailable, using local fallback", zap.Error(err))
+		}
+	}
+
+	fallbackRequests.Inc()
+	return f.secondary.Allow(ctx, key, limit)
+}
@@ -0,0 +1,48 @@
+// internal/infra/ratelimit/limiter.go
+// 이 파일은 요청 속도 제한기 인터페이스와 설정에 따른 구현 선택을 정의합니다.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// 설정에서 사용하는 저장소 이름
+const (
+	BackendMemory = "memory" // 프로세스 메모리 (레플리카마다 따로 계산)
+	BackendRedis  = "redis"  // Redis 공유 (모든 레플리카가 하나의 한도를 나눠 씀)
+)
+
+// Limit은 키 하나에 적용할 제한입니다.
+type Limit struct {
+	Rate  float64 // 초당 허용 요청 수
+	Burst int     // 한 번에 허용하는 최대 요청 수
+}
+
+// Result는 제한 검사 결과입니다.
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration // 거부된 경우 다음 요청이 허용될 때까지 남은 시간 (알 수 없으면 0)
+}
+
+// Limiter는 키(클라이언트 IP 등)별로 요청을 허용할지 결정합니다.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// New는 backend에 맞는 Limiter를 반환합니다.
+// redis를 선택하면 Redis 장애 시 local로 대신 제한하는 Fallback으로 감쌉니다.
+func New(backend string, local *Local, rdb *redis.Client, cfg RedisConfig, log *zap.Logger) (Limiter, error) {
+	switch backend {
+	case "", BackendMemory:
+		return local, nil
+	case BackendRedis:
+		return NewFallback(NewRedis(rdb, cfg), local, log), nil
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown backend %q", backend)
+	}
+}
@@ -0,0 +1,163 @@
+// internal/infra/ratelimit/local.go
+// 이 파일은 프로세스 메모리에 토큰 버킷을 보관하는 Limiter 구현을 정의합니다.
+package ratelimit
+
+import (
+	"context"
+	"hash/maphash"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// localShards는 클라이언트 저장소의 샤드 수입니다 (락 경합 완화).
+const localShards = 32
+
+var (
+	localClients = metrics.Factory.NewGauge(prometheus.GaugeOpts{
+		Name: "rate_limiter_tracked_clients",
+		Help: "Number of clients currently tracked by the in-memory rate limiter",
+	})
+	localEvictions = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limiter_evictions_total",
+		Help: "Number of clients evicted from the rate limiter, by reason (idle, capacity)",
+	}, []string{"reason"})
+)
+
+type localClient struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// localShard는 샤드 하나의 클라이언트 맵과 그 락입니다.
+type localShard struct {
+	mu      sync.Mutex
+	clients map[string]*localClient
+}
+
+// Local은 키별 토큰 버킷을 메모리에 보관하는 Limiter입니다.
+// 여러 RPC 고루틴에서 동시에 사용할 수 있으며, ttl 동안 요청이 없는 키는 Run이 정리합니다.
+type Local struct {
+	shards     [localShards]localShard
+	seed       maphash.Seed
+	ttl        time.Duration
+	maxClients int // 샤드당 상한 = maxClients / localShards (0이면 무제한)
+}
+
+// NewLocal은 메모리 Limiter를 생성합니다.
+// maxClients를 넘으면 같은 샤드에서 가장 오래 요청이 없던 키를 내보냅니다 (0 이하이면 무제한).
+func NewLocal(ttl time.Duration, maxClients int) *Local {
+	l := &Local{
+		seed:       maphash.MakeSeed(),
+		ttl:        ttl,
+		maxClients: maxClients,
+	}
+	for i := range l.shards {
+		l.shards[i].clients = make(map[string]*localClient)
+	}
+	return l
+}
+
+func (l *Local) shard(key string) *localShard {
+	return &l.shards[maphash.String(l.seed, key)%localShards]
+}
+
+// shardCap은 샤드당 최대 키 수입니다 (0이면 무제한).
+func (l *Local) shardCap() int {
+	if l.maxClients <= 0 {
+		return 0
+	}
+	return max(l.maxClients/localShards, 1)
+}
+
+// Allow는 key의 토큰을 하나 소비할 수 있는지 확인합니다. 에러를 반환하지 않습니다.
+func (l *Local) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now()
+	s := l.shard(key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	client, exists := s.clients[key]
+	if !exists {
+		if capacity := l.shardCap(); capacity > 0 && len(s.clients) >= capacity {
+			l.evictOldest(s)
+		}
+		client = &localClient{limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		s.clients[key] = client
+		localClients.Inc()
+	} else if client.limiter.Limit() != rate.Limit(limit.Rate) || client.limiter.Burst() != limit.Burst {
+		// 설정이 바뀐 경우 기존 버킷에 새 한도를 적용
+		client.limiter.SetLimitAt(now, rate.Limit(limit.Rate))
+		client.limiter.SetBurstAt(now, limit.Burst)
+	}
+	client.lastSeen = now
+
+	r := client.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return Result{}, nil
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return Result{RetryAfter: delay}, nil
+	}
+	return Result{Allowed: true}, nil
+}
+
+// evictOldest는 샤드에서 가장 오래 요청이 없던 키를 제거합니다. s.mu를 잡은 상태로 호출해야 합니다.
+func (l *Local) evictOldest(s *localShard) {
+	var (
+		oldestKey  string
+		oldestSeen time.Time
+	)
+	for key, c := range s.clients {
+		if oldestKey == "" || c.lastSeen.Before(oldestSeen) {
+			oldestKey, oldestSeen = key, c.lastSeen
+		}
+	}
+	if oldestKey == "" {
+		return
+	}
+	delete(s.clients, oldestKey)
+	localClients.Dec()
+	localEvictions.WithLabelValues("capacity").Inc()
+}
+
+// Run은 ctx가 취소될 때까지 주기적으로 ttl 동안 요청이 없던 키를 제거합니다.
+func (l *Local) Run(ctx context.Context) {
+	if l.ttl <= 0 {
+		return
+	}
+	ticker := time.NewTicker(max(l.ttl/2, time.Second))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			l.evictIdle(now)
+		}
+	}
+}
+
+// evictIdle은 now 기준으로 ttl보다 오래 요청이 없던 키를 제거합니다.
+// 샤드 단위로 락을 잡으므로 정리 중에도 다른 샤드의 요청은 막히지 않습니다.
+func (l *Local) evictIdle(now time.Time) {
+	cutoff := now.Add(-l.ttl)
+	for i := range l.shards {
+		s := &l.shards[i]
+		s.mu.Lock()
+		for key, c := range s.clients {
+			if c.lastSeen.Before(cutoff) {
+				delete(s.clients, key)
+				localClients.Dec()
+				localEvictions.WithLabelValues("idle").Inc()
+			}
+		}
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// tracked는 l이 보관 중인 키 수입니다.
+func (l *Local) tracked() int {
+	n := 0
+	for i := range l.shards {
+		s := &l.shards[i]
+		s.mu.Lock()
+		n += len(s.clients)
+		s.mu.Unlock()
+	}
+	return n
+}
+
+// has는 key가 보관 중인지 확인합니다.
+func (l *Local) has(key string) bool {
+	s := l.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.clients[key]
+	return ok
+}
+
+// 버킷이 사실상 다시 차지 않는 한도 (테스트 시간 동안 토큰 재충전 없음)
+var noRefill = Limit{Rate: 0.001, Burst: 10}
+
+func TestLocalConcurrentAllowSharedKey(t *testing.T) {
+	l := NewLocal(time.Hour, 0)
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				res, err := l.Allow(context.Background(), "shared", noRefill)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if res.Allowed {
+					allowed.Add(1)
+				} else if res.RetryAfter <= 0 {
+					t.Error("denied result without retry-after")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := allowed.Load(); got != int64(noRefill.Burst) {
+		t.Fatalf("allowed %d requests across goroutines, want exactly burst %d", got, noRefill.Burst)
+	}
+}
+
+func TestLocalConcurrentDistinctKeysWithEviction(t *testing.T) {
+	const maxClients = 64
+	l := NewLocal(time.Millisecond, maxClients)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				key := fmt.Sprintf("client-%d-%d", g, i%100)
+				if _, err := l.Allow(ctx, key, Limit{Rate: 100, Burst: 5}); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%50 == 0 {
+					l.evictIdle(time.Now()) // Run의 정리와 요청이 동시에 진행되는 경우
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if got := l.tracked(); got > maxClients {
+		t.Fatalf("tracked %d clients, want at most max_clients %d", got, maxClients)
+	}
+}
+
+func TestLocalEvictsOldestAtCapacity(t *testing.T) {
+	// 샤드당 1개 → 같은 샤드의 새 키가 기존 키를 내보냄
+	l := NewLocal(time.Hour, localShards)
+	ctx := context.Background()
+
+	first := "first"
+	if _, err := l.Allow(ctx, first, noRefill); err != nil {
+		t.Fatal(err)
+	}
+	var second string
+	for i := 0; ; i++ {
+		second = fmt.Sprintf("key-%d", i)
+		if second != first && l.shard(second) == l.shard(first) {
+			break
+		}
+	}
+	if _, err := l.Allow(ctx, second, noRefill); err != nil {
+		t.Fatal(err)
+	}
+
+	if l.has(first) {
+		t.Fatal("oldest client was not evicted when the shard reached capacity")
+	}
+	if !l.has(second) {
+		t.Fatal("new client was not tracked")
+	}
+	if got := l.tracked(); got != 1 {
+		t.Fatalf("tracked %d clients, want 1", got)
+	}
+}
+
+func TestLocalEvictsIdleClientsAfterTTL(t *testing.T) {
+	const ttl = time.Minute
+	l := NewLocal(ttl, 0)
+	ctx := context.Background()
+	for _, key := range []string{"a", "b"} {
+		if _, err := l.Allow(ctx, key, noRefill); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l.evictIdle(time.Now().Add(ttl / 2))
+	if got := l.tracked(); got != 2 {
+		t.Fatalf("tracked %d clients before ttl, want 2", got)
+	}
+
+	l.evictIdle(time.Now().Add(ttl + time.Second))
+	if got := l.tracked(); got != 0 {
+		t.Fatalf("tracked %d clients after ttl, want 0", got)
+	}
+
+	// 정리된 키는 새 버킷으로 다시 시작
+	res, err := l.Allow(ctx, "a", noRefill)
+	if err != nil || !res.Allowed {
+		t.Fatalf("Allow after eviction = %+v, %v; want allowed", res, err)
+	}
+}
+
+func TestLocalBurstRefill(t *testing.T) {
+	l := NewLocal(time.Hour, 0)
+	ctx := context.Background()
+	limit := Limit{Rate: 20, Burst: 3} // 50ms마다 토큰 1개
+
+	for i := 0; i < limit.Burst; i++ {
+		if res, _ := l.Allow(ctx, "k", limit); !res.Allowed {
+			t.Fatalf("request %d within burst denied", i+1)
+		}
+	}
+	res, _ := l.Allow(ctx, "k", limit)
+	if res.Allowed {
+		t.Fatal("request beyond burst allowed")
+	}
+	if res.RetryAfter <= 0 || res.RetryAfter > 50*time.Millisecond {
+		t.Fatalf("retry-after = %v, want (0, 50ms]", res.RetryAfter)
+	}
+
+	time.Sleep(res.RetryAfter + 5*time.Millisecond)
+	if res, _ := l.Allow(ctx, "k", limit); !res.Allowed {
+		t.Fatal("request after refill denied")
+	}
+	if res, _ := l.Allow(ctx, "k", limit); res.Allowed {
+		t.Fatal("only one token should have been refilled")
+	}
+}
@@ -0,0 +1,156 @@
+// internal/infra/ratelimit/policy.go
+// 이 파일은 gRPC 메서드별 속도 제한 정책과 정책 파일 로드를 정의합니다.
+package ratelimit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 정책 파일의 제한 대상 이름 (버킷 키와 메트릭 라벨로도 사용)
+const (
+	DimensionIP     = "ip"     // 클라이언트 IP
+	DimensionTarget = "target" // 요청의 대상 이메일/전화번호
+	DimensionUser   = "user"   // 인증된 사용자 ID
+)
+
+// DefaultScope는 정책 테이블에 없는 메서드들이 함께 쓰는 버킷 범위 이름입니다.
+const DefaultScope = "*"
+
+// LimitSpec은 정책 파일에 적는 제한 값입니다. 예: {rate: 5, per: 1h, burst: 5} → 시간당 5회.
+type LimitSpec struct {
+	Rate  float64       `yaml:"rate"`
+	Per   time.Duration `yaml:"per"` // 비어 있으면 1초
+	Burst int           `yaml:"burst"`
+}
+
+// Limit은 LimitSpec을 초당 비율로 변환합니다.
+func (s LimitSpec) Limit() Limit {
+	per := s.Per
+	if per <= 0 {
+		per = time.Second
+	}
+	return Limit{Rate: s.Rate / per.Seconds(), Burst: s.Burst}
+}
+
+func (s LimitSpec) validate() error {
+	if s.Rate <= 0 {
+		return errors.New("rate must be positive")
+	}
+	if s.Burst <= 0 {
+		return errors.New("burst must be positive")
+	}
+	if s.Per < 0 {
+		return errors.New("per must not be negative")
+	}
+	return nil
+}
+
+// Policy는 메서드 하나에 적용할 대상별 제한입니다. nil인 대상은 제한하지 않습니다.
+type Policy struct {
+	IP     *LimitSpec `yaml:"ip"`
+	Target *LimitSpec `yaml:"target"`
+	User   *LimitSpec `yaml:"user"`
+}
+
+// Dimensions는 설정된 대상별 제한을 검사 순서(ip, target, user)대로 반환합니다.
+func (p Policy) Dimensions() []DimensionLimit {
+	var out []DimensionLimit
+	for _, d := range []struct {
+		name string
+		spec *LimitSpec
+	}{
+		{DimensionIP, p.IP},
+		{DimensionTarget, p.Target},
+		{DimensionUser, p.User},
+	} {
+		if d.spec != nil {
+			out = append(out, DimensionLimit{Dimension: d.name, Limit: d.spec.Limit()})
+		}
+	}
+	return out
+}
+
+// DimensionLimit은 대상 하나와 그 제한입니다.
+type DimensionLimit struct {
+	Dimension string
+	Limit     Limit
+}
+
+// PolicyTable은 gRPC 전체 메서드 이름("/auth.AuthService/SendVerification")별 정책입니다.
+type PolicyTable struct {
+	Default Policy            `yaml:"default"`
+	Methods map[string]Policy `yaml:"methods"`
+}
+
+// DefaultPolicyTable은 모든 메서드에 IP별 limit 하나만 적용하는 정책입니다.
+// limit.Rate가 0 이하이면 제한하지 않습니다.
+func DefaultPolicyTable(limit LimitSpec) *PolicyTable {
+	if limit.Rate <= 0 {
+		return &PolicyTable{}
+	}
+	return &PolicyTable{Default: Policy{IP: &limit}}
+}
+
+// Lookup은 method에 적용할 정책과 버킷 범위를 반환합니다.
+// 테이블에 있는 메서드는 메서드 이름을, 없는 메서드는 DefaultScope를 범위로 사용하므로
+// 기본 정책을 쓰는 메서드들은 버킷을 공유합니다.
+func (t *PolicyTable) Lookup(method string) (string, Policy) {
+	if p, ok := t.Methods[method]; ok {
+		return method, p
+	}
+	return DefaultScope, t.Default
+}
+
+// Validate는 정책 값이 올바른지 검사합니다.
+func (t *PolicyTable) Validate() error {
+	var errs []error
+	check := func(scope string, p Policy) {
+		for name, spec := range map[string]*LimitSpec{DimensionIP: p.IP, DimensionTarget: p.Target, DimensionUser: p.User} {
+			if spec == nil {
+				continue
+			}
+			if err := spec.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", scope, name, err))
+			}
+		}
+	}
+	check("default", t.Default)
+	for method, p := range t.Methods {
+		if len(method) == 0 || method[0] != '/' {
+			errs = append(errs, fmt.Errorf("methods: %q is not a full method name (/package.Service/Method)", method))
+			continue
+		}
+		check(method, p)
+	}
+	return errors.Join(errs...)
+}
+
+// LoadPolicies는 YAML 정책 파일을 읽습니다.
+// 파일에 default 정책이 없으면 fallback을 기본 정책으로 사용합니다.
+func LoadPolicies(path string, fallback Policy) (*PolicyTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("ratelimit: read policy file: %w", err)
+	}
+
+	var t PolicyTable
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("ratelimit: parse policy file %s: %w", path, err)
+	}
+	if t.Default == (Policy{}) {
+		t.Default = fallback
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("ratelimit: invalid policy file %s: %w", path, err)
+	}
+	return &t, nil
+}
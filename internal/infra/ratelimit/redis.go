@@ -0,0 +1,83 @@
+// internal/infra/ratelimit/redis.go
+// 이 파일은 Redis에 GCRA 상태를 공유하는 분산 Limiter 구현을 정의합니다.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// gcraScript는 GCRA(Generic Cell Rate Algorithm)로 요청 하나를 원자적으로 검사합니다.
+// 키에는 다음 요청의 이론적 도착 시각(TAT, 마이크로초)만 저장하며,
+// 레플리카 간 시계 차이를 피하기 위해 Redis 서버 시각을 사용합니다.
+//
+//	ARGV[1] = 요청 간격 (마이크로초, 1초 / rate)
+//	ARGV[2] = 버스트 크기
+//	반환값 = {허용 여부(1/0), 재시도까지 남은 시간(마이크로초)}
+var gcraScript = redis.NewScript(`
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000000 + tonumber(t[2])
+local interval = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]) or now)
+if tat < now then
+  tat = now
+end
+
+local new_tat = tat + interval
+local allow_at = new_tat - burst * interval
+if now < allow_at then
+  return {0, math.ceil(allow_at - now)}
+end
+
+redis.call('SET', KEYS[1], string.format('%.0f', new_tat), 'PX', math.ceil((new_tat - now) / 1000))
+return {1, 0}
+`)
+
+// RedisConfig는 Redis Limiter 설정입니다.
+type RedisConfig struct {
+	Timeout time.Duration // 검사 한 번에 허용하는 시간 (초과 시 에러, 기본 100ms)
+}
+
+// Redis는 모든 레플리카가 같은 한도를 공유하도록 Redis에 상태를 보관하는 Limiter입니다.
+type Redis struct {
+	rdb     *redis.Client
+	timeout time.Duration
+}
+
+func NewRedis(rdb *redis.Client, cfg RedisConfig) *Redis {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 100 * time.Millisecond
+	}
+	return &Redis{rdb: rdb, timeout: cfg.Timeout}
+}
+
+// Allow는 key의 요청 하나를 허용할지 Redis에서 원자적으로 결정합니다.
+func (r *Redis) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return Result{}, nil
+	}
+	interval := int64(math.Ceil(float64(time.Second/time.Microsecond) / limit.Rate))
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := gcraScript.Run(ctx, r.rdb, []string{redisKeyPrefix + key}, interval, limit.Burst).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: redis: %w", err)
+	}
+	if len(res) != 2 {
+		return Result{}, fmt.Errorf("ratelimit: redis: unexpected script result %v", res)
+	}
+	return Result{
+		Allowed:    res[0] == 1,
+		RetryAfter: time.Duration(res[1]) * time.Microsecond,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *Redis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return mr, NewRedis(rdb, RedisConfig{Timeout: time.Second})
+}
+
+func TestRedisGCRAAllowDeny(t *testing.T) {
+	mr, r := newTestRedis(t)
+	ctx := context.Background()
+	limit := Limit{Rate: 1, Burst: 3}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mr.SetTime(now)
+
+	for i := 0; i < limit.Burst; i++ {
+		res, err := r.Allow(ctx, "ip:1", limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Allowed {
+			t.Fatalf("request %d within burst denied", i+1)
+		}
+	}
+
+	res, err := r.Allow(ctx, "ip:1", limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Allowed {
+		t.Fatal("request beyond burst allowed")
+	}
+	if res.RetryAfter != time.Second {
+		t.Fatalf("retry-after = %v, want 1s", res.RetryAfter)
+	}
+
+	// 다른 키는 따로 계산
+	if res, err := r.Allow(ctx, "ip:2", limit); err != nil || !res.Allowed {
+		t.Fatalf("other key = %+v, %v; want allowed", res, err)
+	}
+
+	// 재시도 시각 직전에는 거부, 지나면 한 건만 허용
+	mr.SetTime(now.Add(999 * time.Millisecond))
+	if res, _ := r.Allow(ctx, "ip:1", limit); res.Allowed {
+		t.Fatal("request before retry-after allowed")
+	} else if res.RetryAfter != time.Millisecond {
+		t.Fatalf("retry-after = %v, want 1ms", res.RetryAfter)
+	}
+	mr.SetTime(now.Add(time.Second))
+	if res, _ := r.Allow(ctx, "ip:1", limit); !res.Allowed {
+		t.Fatal("request after retry-after denied")
+	}
+	if res, _ := r.Allow(ctx, "ip:1", limit); res.Allowed {
+		t.Fatal("only one request should be allowed after one interval")
+	}
+}
+
+func TestRedisGCRAKeyExpiresWhenBucketIsFull(t *testing.T) {
+	mr, r := newTestRedis(t)
+	ctx := context.Background()
+	mr.SetTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	if _, err := r.Allow(ctx, "ip:1", Limit{Rate: 10, Burst: 5}); err != nil {
+		t.Fatal(err)
+	}
+	ttl := mr.TTL(redisKeyPrefix + "ip:1")
+	if ttl <= 0 || ttl > 100*time.Millisecond {
+		t.Fatalf("key ttl = %v, want (0, 100ms] (one interval)", ttl)
+	}
+}
+
+func TestRedisZeroLimitDenies(t *testing.T) {
+	_, r := newTestRedis(t)
+	res, err := r.Allow(context.Background(), "ip:1", Limit{})
+	if err != nil || res.Allowed {
+		t.Fatalf("zero limit = %+v, %v; want denied", res, err)
+	}
+}
+
+// stubLimiter는 정해진 결과를 반환하고 호출 횟수를 셉니다.
+type stubLimiter struct {
+	res   Result
+	err   error
+	calls int
+}
+
+func (s *stubLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	s.calls++
+	return s.res, s.err
+}
+
+func TestFallbackUsesLocalWhenRedisFails(t *testing.T) {
+	mr, r := newTestRedis(t)
+	local := NewLocal(time.Hour, 0)
+	f := NewFallback(r, local, zap.NewNop())
+	ctx := context.Background()
+	limit := Limit{Rate: 0.001, Burst: 2}
+
+	if res, err := f.Allow(ctx, "ip:1", limit); err != nil || !res.Allowed {
+		t.Fatalf("healthy redis = %+v, %v; want allowed", res, err)
+	}
+	if local.tracked() != 0 {
+		t.Fatal("local limiter used while redis is healthy")
+	}
+
+	mr.Close()
+	for i := 0; i < limit.Burst; i++ {
+		res, err := f.Allow(ctx, "ip:1", limit)
+		if err != nil {
+			t.Fatalf("fallback returned error: %v", err)
+		}
+		if !res.Allowed {
+			t.Fatalf("fallback request %d denied", i+1)
+		}
+	}
+	if res, _ := f.Allow(ctx, "ip:1", limit); res.Allowed {
+		t.Fatal("local fallback did not enforce the limit")
+	}
+	if !local.has("ip:1") {
+		t.Fatal("local limiter not used after redis failure")
+	}
+}
+
+func TestFallbackUsesLocalWhenRedisTimesOut(t *testing.T) {
+	// 연결은 받지만 응답하지 않는 서버
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			t.Cleanup(func() { _ = conn.Close() })
+		}
+	}()
+
+	rdb := redis.NewClient(&redis.Options{Addr: lis.Addr().String(), MaxRetries: -1})
+	t.Cleanup(func() { _ = rdb.Close() })
+	f := NewFallback(NewRedis(rdb, RedisConfig{Timeout: 20 * time.Millisecond}), NewLocal(time.Hour, 0), zap.NewNop())
+
+	start := time.Now()
+	res, err := f.Allow(context.Background(), "ip:1", Limit{Rate: 1, Burst: 1})
+	if err != nil || !res.Allowed {
+		t.Fatalf("fallback on timeout = %+v, %v; want allowed", res, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("check took %v, redis timeout not applied", elapsed)
+	}
+}
+
+func TestFallbackCooldownAndRecovery(t *testing.T) {
+	primary := &stubLimiter{err: errors.New("connection refused")}
+	secondary := &stubLimiter{res: Result{Allowed: true}}
+	f := NewFallback(primary, secondary, zap.NewNop())
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if res, err := f.Allow(ctx, "k", Limit{Rate: 1, Burst: 1}); err != nil || !res.Allowed {
+			t.Fatalf("Allow = %+v, %v", res, err)
+		}
+	}
+	// 첫 실패 후 쿨다운 동안에는 primary를 다시 시도하지 않음
+	if primary.calls != 1 || secondary.calls != 3 {
+		t.Fatalf("primary calls = %d, secondary calls = %d; want 1, 3", primary.calls, secondary.calls)
+	}
+
+	// 쿨다운이 지나고 primary가 복구되면 다시 primary 사용
+	primary.err, primary.res = nil, Result{RetryAfter: time.Second}
+	f.downUntil.Store(time.Now().Add(-time.Millisecond).UnixNano())
+	res, err := f.Allow(ctx, "k", Limit{Rate: 1, Burst: 1})
+	if err != nil || res.Allowed || res.RetryAfter != time.Second {
+		t.Fatalf("recovered Allow = %+v, %v; want primary result", res, err)
+	}
+	if f.downUntil.Load() != 0 {
+		t.Fatal("fallback state not reset after recovery")
+	}
+	if secondary.calls != 3 {
+		t.Fatalf("secondary used after recovery (%d calls)", secondary.calls)
+	}
+}
@@ -28,12 +28,18 @@ func NewTwilioProvider(config config.TwilioConfig) SMSProvider {
 	}
 }
 
+// Name 메트릭과 로그에 사용할 프로바이더 이름을 반환합니다
+func (p *twilioProvider) Name() string {
+	return "twilio"
+}
+
 // SendVerificationSMS Twilio API를 사용해 SMS 인증 코드를 발송합니다
 func (p *twilioProvider) SendVerificationSMS(ctx context.Context, phoneNumber, code string) error {
+	body := fmt.Sprintf("인증 코드: %s", code)
 	params := &twilioApi.CreateMessageParams{
 		To:   &phoneNumber,
 		From: &p.from,
-		Body: fmt.Sprintf("인증 코드: %s", code),
+		Body: &body,
 	}
 
 	_, err := p.client.Api.CreateMessage(params)
@@ -3,10 +3,29 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+
 	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// startSpan은 쿼리 하나에 대한 클라이언트 스팬을 시작합니다 (쿼리 파라미터는 기록하지 않음).
+func startSpan(ctx context.Context, operation, table, query string) (context.Context, trace.Span) {
+	return tracing.StartClient(ctx, "internal/repository/postgres", operation+" "+table,
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBCollectionName(table),
+		semconv.DBQueryText(query),
+	)
+}
+
+// uniqueViolation은 PostgreSQL의 unique 제약 위반 에러 코드입니다.
+const uniqueViolation = "23505"
+
 type UserRepository struct {
 	db *sql.DB
 }
@@ -15,22 +34,39 @@ func NewUserRepository(db *sql.DB) domain.UserRepository {
 	return &UserRepository{db: db}
 }
 
-func (r *UserRepository) Create(ctx context.Context, user *domain.User) (string, error) {
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) (_ string, err error) {
 	id := uuid.New().String()
 	query := `INSERT INTO users (id, email, password_hash, created_at) VALUES ($1, $2, $3, NOW())`
+	ctx, span := startSpan(ctx, "INSERT", "users", query)
+	defer func() { tracing.End(span, err) }()
+
 	if _, err := r.db.ExecContext(ctx, query, id, user.Email, user.PasswordHash); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return "", domain.ErrAlreadyExists
+		}
 		return "", err
 	}
 	return id, nil
 }
 
-func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (_ *domain.User, err error) {
 	var u domain.User
 	query := `SELECT id, email, password_hash, created_at FROM users WHERE email = $1`
+	ctx, span := startSpan(ctx, "SELECT", "users", query)
+	// 조회 결과 없음은 정상 흐름이므로 스팬 에러로 기록하지 않음
+	defer func() {
+		if errors.Is(err, domain.ErrNotFound) {
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+
 	row := r.db.QueryRowContext(ctx, query, email)
 	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
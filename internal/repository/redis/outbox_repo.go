@@ -0,0 +1,265 @@
+// internal/repository/redis/outbox_repo.go
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	outboxStream    = "mail_outbox"         // 발송 대기 스트림
+	outboxGroup     = "mail_workers"        // 워커 컨슈머 그룹
+	outboxRetryKey  = "mail_outbox:retry"   // 재시도 대기 (score = 재시도 시각, ms)
+	outboxDeadKey   = "mail_outbox:dead"    // 데드레터 스트림
+	outboxDeadMax   = 10000                 // 데드레터 스트림 최대 보관 건수 (넘으면 오래된 것부터 삭제)
+	outboxSentKey   = "mail_outbox:sent:"   // 발송 완료된 멱등성 키
+	outboxClaimIdle = 5 * time.Minute       // 이 시간 이상 Ack 되지 않은 메시지는 다른 워커가 회수
+	outboxSentTTL   = 24 * time.Hour        // 멱등성 키 보관 기간
+	outboxPromoteN  = 100                   // 한 번에 재시도 큐에서 꺼낼 최대 건수
+	outboxPayload   = "payload"             // 스트림 엔트리 필드명
+	outboxDeadError = "unparseable payload" // 파싱 불가 메시지의 데드레터 사유
+)
+
+// promoteScript는 재시도 시각이 지난 메시지를 재시도 큐에서 스트림으로 원자적으로 옮깁니다.
+var promoteScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, item in ipairs(items) do
+  redis.call('XADD', KEYS[2], '*', 'payload', item)
+  redis.call('ZREM', KEYS[1], item)
+end
+return #items
+`)
+
+// OutboxRepository는 Redis Streams 기반의 이메일 아웃박스 저장소입니다.
+type OutboxRepository struct {
+	rdb *redis.Client
+}
+
+func NewOutboxRepository(rdb *redis.Client) *OutboxRepository {
+	return &OutboxRepository{rdb: rdb}
+}
+
+// EnsureGroup은 스트림과 컨슈머 그룹을 생성합니다. 이미 존재하면 무시합니다.
+func (r *OutboxRepository) EnsureGroup(ctx context.Context) error {
+	err := r.rdb.XGroupCreateMkStream(ctx, outboxStream, outboxGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// Enqueue는 메시지를 발송 대기 스트림에 적재합니다.
+func (r *OutboxRepository) Enqueue(ctx context.Context, msg *domain.OutboxMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return r.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: outboxStream,
+		Values: map[string]interface{}{outboxPayload: payload},
+	}).Err()
+}
+
+// Fetch는 처리할 메시지를 최대 count 건 가져옵니다.
+// 먼저 오래 Ack 되지 않은(워커 장애 등) 메시지를 회수하고, 없으면 새 메시지를 block 만큼 기다립니다.
+func (r *OutboxRepository) Fetch(ctx context.Context, consumer string, count int64, block time.Duration) ([]*domain.OutboxMessage, error) {
+	claimed, _, err := r.rdb.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:   outboxStream,
+		Group:    outboxGroup,
+		MinIdle:  outboxClaimIdle,
+		Start:    "0-0",
+		Count:    count,
+		Consumer: consumer,
+	}).Result()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	if len(claimed) > 0 {
+		return r.decode(ctx, claimed)
+	}
+
+	streams, err := r.rdb.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    outboxGroup,
+		Consumer: consumer,
+		Streams:  []string{outboxStream, ">"},
+		Count:    count,
+		Block:    block,
+	}).Result()
+	if err == redis.Nil {
+		return nil, nil // 대기 시간 내 새 메시지 없음
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []redis.XMessage
+	for _, s := range streams {
+		entries = append(entries, s.Messages...)
+	}
+	return r.decode(ctx, entries)
+}
+
+// decode는 스트림 엔트리를 도메인 메시지로 변환합니다.
+// 파싱할 수 없는 엔트리는 재시도해도 의미가 없으므로 바로 데드레터 스트림으로 옮깁니다.
+func (r *OutboxRepository) decode(ctx context.Context, entries []redis.XMessage) ([]*domain.OutboxMessage, error) {
+	msgs := make([]*domain.OutboxMessage, 0, len(entries))
+	for _, e := range entries {
+		raw, _ := e.Values[outboxPayload].(string)
+
+		var msg domain.OutboxMessage
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			if err := r.moveToDead(ctx, e.ID, raw, outboxDeadError); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		msg.StreamID = e.ID
+		msgs = append(msgs, &msg)
+	}
+	return msgs, nil
+}
+
+// Ack는 발송이 끝난 메시지를 스트림에서 제거합니다.
+func (r *OutboxRepository) Ack(ctx context.Context, msg *domain.OutboxMessage) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAck(ctx, outboxStream, outboxGroup, msg.StreamID)
+		pipe.XDel(ctx, outboxStream, msg.StreamID)
+		return nil
+	})
+	return err
+}
+
+// Retry는 메시지를 스트림에서 제거하고 at 시각에 재시도되도록 재시도 큐에 넣습니다.
+func (r *OutboxRepository) Retry(ctx context.Context, msg *domain.OutboxMessage, at time.Time) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, outboxRetryKey, &redis.Z{Score: float64(at.UnixMilli()), Member: payload})
+		pipe.XAck(ctx, outboxStream, outboxGroup, msg.StreamID)
+		pipe.XDel(ctx, outboxStream, msg.StreamID)
+		return nil
+	})
+	return err
+}
+
+// DeadLetter는 메시지를 데드레터 스트림으로 옮깁니다.
+func (r *OutboxRepository) DeadLetter(ctx context.Context, msg *domain.OutboxMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return r.moveToDead(ctx, msg.StreamID, string(payload), msg.LastError)
+}
+
+func (r *OutboxRepository) moveToDead(ctx context.Context, streamID, payload, reason string) error {
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: outboxDeadKey,
+			MaxLen: outboxDeadMax,
+			Approx: true,
+			Values: map[string]interface{}{outboxPayload: payload, "reason": reason},
+		})
+		pipe.XAck(ctx, outboxStream, outboxGroup, streamID)
+		pipe.XDel(ctx, outboxStream, streamID)
+		return nil
+	})
+	return err
+}
+
+// PromoteDue는 재시도 시각이 지난 메시지를 발송 대기 스트림으로 되돌리고 옮긴 건수를 반환합니다.
+func (r *OutboxRepository) PromoteDue(ctx context.Context, now time.Time) (int, error) {
+	n, err := promoteScript.Run(ctx, r.rdb, []string{outboxRetryKey, outboxStream}, now.UnixMilli(), outboxPromoteN).Int()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return n, err
+}
+
+// IsSent는 멱등성 키에 해당하는 메시지가 이미 발송되었는지 확인합니다.
+func (r *OutboxRepository) IsSent(ctx context.Context, key string) (bool, error) {
+	n, err := r.rdb.Exists(ctx, outboxSentKey+key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkSent는 멱등성 키를 발송 완료로 기록합니다.
+func (r *OutboxRepository) MarkSent(ctx context.Context, key string) error {
+	return r.rdb.Set(ctx, outboxSentKey+key, "1", outboxSentTTL).Err()
+}
+
+// Stats는 큐별 적재 건수를 조회합니다.
+func (r *OutboxRepository) Stats(ctx context.Context) (domain.OutboxStats, error) {
+	var stats domain.OutboxStats
+
+	pipe := r.rdb.Pipeline()
+	streamLen := pipe.XLen(ctx, outboxStream)
+	pending := pipe.XPending(ctx, outboxStream, outboxGroup)
+	retrying := pipe.ZCard(ctx, outboxRetryKey)
+	dead := pipe.XLen(ctx, outboxDeadKey)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return stats, err
+	}
+
+	stats.Pending = pending.Val().Count
+	stats.Ready = streamLen.Val() - stats.Pending
+	stats.Retrying = retrying.Val()
+	stats.DeadLettered = dead.Val()
+	return stats, nil
+}
+
+// Redrive는 데드레터 스트림의 메시지를 최대 limit 건 발송 대기 스트림으로 되돌립니다.
+// 되돌린 메시지는 시도 횟수가 초기화됩니다. 이미 만료된 메시지(인증 코드 등)는 되돌리지 않고 삭제합니다.
+// 되돌린 건수와 만료되어 삭제한 건수를 반환합니다.
+func (r *OutboxRepository) Redrive(ctx context.Context, limit int64) (redriven, expired int, err error) {
+	entries, err := r.rdb.XRangeN(ctx, outboxDeadKey, "-", "+", limit).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	now := time.Now()
+	for _, e := range entries {
+		raw, _ := e.Values[outboxPayload].(string)
+
+		var msg domain.OutboxMessage
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			continue // 파싱 불가 메시지는 데드레터에 남겨 수동 확인
+		}
+		if msg.Expired(now) {
+			if err := r.rdb.XDel(ctx, outboxDeadKey, e.ID).Err(); err != nil {
+				return redriven, expired, err
+			}
+			expired++
+			continue
+		}
+		msg.Attempts = 0
+		msg.LastError = ""
+		payload, err := json.Marshal(&msg)
+		if err != nil {
+			return redriven, expired, err
+		}
+
+		_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: outboxStream,
+				Values: map[string]interface{}{outboxPayload: payload},
+			})
+			pipe.XDel(ctx, outboxDeadKey, e.ID)
+			return nil
+		})
+		if err != nil {
+			return redriven, expired, err
+		}
+		redriven++
+	}
+	return redriven, expired, nil
+}
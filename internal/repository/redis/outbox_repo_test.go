@@ -0,0 +1,92 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestOutbox(t *testing.T) *OutboxRepository {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	repo := NewOutboxRepository(rdb)
+	if err := repo.EnsureGroup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+// deadLetter는 msg를 적재한 뒤 데드레터로 옮깁니다.
+func deadLetter(t *testing.T, repo *OutboxRepository, msg *domain.OutboxMessage) {
+	t.Helper()
+	ctx := context.Background()
+	if err := repo.Enqueue(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := repo.rdb.XRange(ctx, outboxStream, "-", "+").Result()
+	if err != nil || len(entries) == 0 {
+		t.Fatalf("XRange = %d entries, %v", len(entries), err)
+	}
+	msg.StreamID = entries[len(entries)-1].ID
+	msg.Attempts, msg.LastError = 3, "550 mailbox unavailable"
+	if err := repo.DeadLetter(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// queued는 발송 대기 스트림의 메시지입니다.
+func queued(t *testing.T, repo *OutboxRepository) []*domain.OutboxMessage {
+	t.Helper()
+	entries, err := repo.rdb.XRange(context.Background(), outboxStream, "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msgs, err := repo.decode(context.Background(), entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msgs
+}
+
+func TestOutboxRedriveDropsExpiredMessages(t *testing.T) {
+	repo := newTestOutbox(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	deadLetter(t, repo, &domain.OutboxMessage{ID: "expired", To: "a@example.com", ExpiresAt: now.Add(-time.Minute)})
+	deadLetter(t, repo, &domain.OutboxMessage{ID: "valid", To: "b@example.com", ExpiresAt: now.Add(time.Minute)})
+	deadLetter(t, repo, &domain.OutboxMessage{ID: "no-expiry", To: "c@example.com"})
+
+	redriven, expired, err := repo.Redrive(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redriven != 2 || expired != 1 {
+		t.Fatalf("Redrive = %d redriven, %d expired; want 2, 1", redriven, expired)
+	}
+
+	stats, err := repo.Stats(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.DeadLettered != 0 {
+		t.Fatalf("dead-letter stream holds %d messages, want 0", stats.DeadLettered)
+	}
+
+	var ids []string
+	for _, m := range queued(t, repo) {
+		ids = append(ids, m.ID)
+		if m.Attempts != 0 || m.LastError != "" {
+			t.Fatalf("redriven message %s kept attempts %d / last error %q", m.ID, m.Attempts, m.LastError)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "valid" || ids[1] != "no-expiry" {
+		t.Fatalf("redriven messages = %v, want [valid no-expiry]", ids)
+	}
+}
@@ -7,9 +7,107 @@ import (
 	"fmt"
 	"time"
 
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
 	"github.com/go-redis/redis/v8"
 )
 
+// 리프레시 토큰 키(refresh_token:<사용자>:<토큰 ID>)는 해시로 세션 상태를 함께 보관합니다.
+//   - created_at: 세션 생성(로그인) 시각, Unix ms
+//   - last_active: 마지막 로그인 또는 토큰 갱신 시각, Unix ms
+//   - session_id: 로그인 세션 ID (토큰을 갱신해도 유지)
+//
+// 이전 버전이 저장한 키는 문자열 "valid"입니다.
+// 사용자별 세션 인덱스(user_sessions:<사용자>)는 토큰 ID를 마지막 활동 시각 순으로 보관하는 ZSET입니다.
+// 세션 수 제한으로 종료한 세션은 폐기 키(revoked_session:<세션 ID>)를 액세스 토큰이 모두 만료될 때까지 남깁니다.
+
+// storeScript는 사용자의 세션 수를 제한하면서 새 리프레시 토큰을 원자적으로 저장합니다.
+// 세션 인덱스(ZSET, score = 마지막 활동 시각)에서 만료된 토큰을 정리한 뒤 최대치에 도달했으면 거부하거나 기존 세션을 종료합니다.
+// 인덱스 도입 전에 저장된 토큰은 인덱스에 없으므로 세지 않습니다.
+// 인덱스의 토큰 키는 스크립트 안에서 만들므로 Redis Cluster에서는 사용할 수 없습니다 (단일 인스턴스/센티널 전용).
+// 종료한 세션은 세션 ID가 기록되어 있으면 폐기 키를 남깁니다 (폐기 기간이 0이면 생략).
+// KEYS: 새 토큰, 세션 인덱스 / ARGV: 생성 시각, 만료 시각 (ms), 최대 세션 수, 처리 방식, 토큰 키 접두사, 새 토큰 ID,
+// 새 세션 ID, 폐기 키 접두사, 폐기 기간 (ms)
+// 반환: {1, 종료한 토큰 ID...} 저장, {0} 거부
+var storeScript = redis.NewScript(`
+local live = {}
+for _, id in ipairs(redis.call('ZRANGE', KEYS[2], 0, -1)) do
+  local created = redis.call('HGET', ARGV[5] .. id, 'created_at')
+  if created then
+    table.insert(live, {id = id, created = tonumber(created)})
+  else
+    redis.call('ZREM', KEYS[2], id)
+  end
+end
+local max, result = tonumber(ARGV[3]), {1}
+if max > 0 and #live >= max then
+  if ARGV[4] == 'reject' then
+    return {0}
+  end
+  if ARGV[4] == 'evict_oldest' then
+    table.sort(live, function(a, b) return a.created < b.created end)
+  end
+  for i = 1, #live - max + 1 do
+    local sid = redis.call('HGET', ARGV[5] .. live[i].id, 'session_id')
+    if sid and sid ~= '' and tonumber(ARGV[9]) > 0 then
+      redis.call('SET', ARGV[8] .. sid, 1, 'PX', ARGV[9])
+    end
+    redis.call('DEL', ARGV[5] .. live[i].id)
+    redis.call('ZREM', KEYS[2], live[i].id)
+    table.insert(result, live[i].id)
+  end
+end
+redis.call('HSET', KEYS[1], 'created_at', ARGV[1], 'last_active', ARGV[1], 'session_id', ARGV[7])
+redis.call('PEXPIREAT', KEYS[1], ARGV[2])
+redis.call('ZADD', KEYS[2], ARGV[1], ARGV[6])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[2]) - tonumber(ARGV[1]) then
+  redis.call('PEXPIREAT', KEYS[2], ARGV[2])
+end
+return result
+`)
+
+// rotateScript는 리프레시 토큰을 검사하고 새 토큰으로 교체하는 과정을 원자적으로 수행합니다.
+// 같은 토큰으로 동시에 갱신해도 하나만 성공합니다.
+// 세션 인덱스에서도 기존 토큰 ID를 새 토큰 ID로 바꾸고 마지막 활동 시각을 갱신합니다.
+// KEYS: 기존 토큰, 새 토큰, 세션 인덱스 / ARGV: 현재 시각, 최대 수명, 최대 유휴 시간, 새 토큰 만료 시각, 기본 생성 시각 (ms), 기존/새 토큰 ID, 세션 ID
+// 반환: 1 교체, 0 토큰 없음, -1 수명 초과, -2 유휴 시간 초과
+var rotateScript = redis.NewScript(`
+local kind = redis.call('TYPE', KEYS[1]).ok
+if kind == 'none' then
+  return 0
+end
+local created, last
+if kind == 'hash' then
+  local v = redis.call('HMGET', KEYS[1], 'created_at', 'last_active')
+  created, last = tonumber(v[1]), tonumber(v[2])
+end
+created = created or tonumber(ARGV[5])
+last = last or created
+local now, lifetime, idle = tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3])
+redis.call('DEL', KEYS[1])
+redis.call('ZREM', KEYS[3], ARGV[6])
+if lifetime > 0 and now - created >= lifetime then
+  return -1
+end
+if idle > 0 and now - last >= idle then
+  return -2
+end
+redis.call('HSET', KEYS[2], 'created_at', created, 'last_active', now, 'session_id', ARGV[8])
+redis.call('PEXPIREAT', KEYS[2], ARGV[4])
+redis.call('ZADD', KEYS[3], now, ARGV[7])
+if redis.call('PTTL', KEYS[3]) < tonumber(ARGV[4]) - now then
+  redis.call('PEXPIREAT', KEYS[3], ARGV[4])
+end
+return 1
+`)
+
+// sessionIndexKey는 사용자의 활성 세션(리프레시 토큰 ID) 인덱스 키입니다.
+func sessionIndexKey(userID string) string {
+	return fmt.Sprintf("user_sessions:%s", userID)
+}
+
+// revokedSessionPrefix는 종료된 세션의 폐기 키 접두사입니다.
+const revokedSessionPrefix = "revoked_session:"
+
 type tokenRepository struct {
 	client *redis.Client
 }
@@ -20,30 +118,86 @@ func NewTokenRepository(client *redis.Client) *tokenRepository {
 	}
 }
 
-func (r *tokenRepository) StoreRefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error {
-	key := fmt.Sprintf("refresh_token:%s:%s", userID, tokenID)
-	duration := time.Until(expiresAt)
+func (r *tokenRepository) StoreRefreshToken(ctx context.Context, userID, tokenID, sessionID string, createdAt, expiresAt time.Time, limit tokenRepo.SessionCap) ([]string, error) {
+	prefix := fmt.Sprintf("refresh_token:%s:", userID)
+	keys := []string{prefix + tokenID, sessionIndexKey(userID)}
+
+	res, err := storeScript.Run(ctx, r.client, keys,
+		createdAt.UnixMilli(),
+		expiresAt.UnixMilli(),
+		limit.Max,
+		limit.Policy,
+		prefix,
+		tokenID,
+		sessionID,
+		revokedSessionPrefix,
+		limit.RevokeFor.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return nil, err
+	}
 
-	return r.client.Set(ctx, key, "valid", duration).Err()
+	if status, _ := res[0].(int64); status == 0 {
+		return nil, tokenRepo.ErrSessionLimit
+	}
+	evicted := make([]string, 0, len(res)-1)
+	for _, id := range res[1:] {
+		evicted = append(evicted, fmt.Sprint(id))
+	}
+	return evicted, nil
 }
 
 func (r *tokenRepository) ValidateRefreshToken(ctx context.Context, userID, tokenID string) (bool, error) {
 	key := fmt.Sprintf("refresh_token:%s:%s", userID, tokenID)
 
-	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
-		return false, nil
-	} else if err != nil {
+	n, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
 		return false, err
 	}
 
-	return val == "valid", nil
+	return n > 0, nil
+}
+
+func (r *tokenRepository) RotateRefreshToken(ctx context.Context, userID, oldTokenID, newTokenID, sessionID string, limits tokenRepo.SessionLimits, fallbackCreatedAt, expiresAt time.Time) error {
+	keys := []string{
+		fmt.Sprintf("refresh_token:%s:%s", userID, oldTokenID),
+		fmt.Sprintf("refresh_token:%s:%s", userID, newTokenID),
+		sessionIndexKey(userID),
+	}
+	res, err := rotateScript.Run(ctx, r.client, keys,
+		time.Now().UnixMilli(),
+		limits.Lifetime.Milliseconds(),
+		limits.IdleTimeout.Milliseconds(),
+		expiresAt.UnixMilli(),
+		fallbackCreatedAt.UnixMilli(),
+		oldTokenID,
+		newTokenID,
+		sessionID,
+	).Int()
+	if err != nil {
+		return err
+	}
+
+	switch res {
+	case 0:
+		return tokenRepo.ErrTokenNotFound
+	case -1:
+		return tokenRepo.ErrSessionExpired
+	case -2:
+		return tokenRepo.ErrSessionIdle
+	}
+	return nil
 }
 
 func (r *tokenRepository) DeleteRefreshToken(ctx context.Context, userID, tokenID string) error {
 	key := fmt.Sprintf("refresh_token:%s:%s", userID, tokenID)
 
-	return r.client.Del(ctx, key).Err()
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.ZRem(ctx, sessionIndexKey(userID), tokenID)
+		return nil
+	})
+	return err
 }
 
 func (r *tokenRepository) DeleteAllUserTokens(ctx context.Context, userID string) error {
@@ -54,9 +208,34 @@ func (r *tokenRepository) DeleteAllUserTokens(ctx context.Context, userID string
 		return err
 	}
 
-	if len(keys) > 0 {
-		return r.client.Del(ctx, keys...).Err()
+	return r.client.Del(ctx, append(keys, sessionIndexKey(userID))...).Err()
+}
+
+// IsSessionRevoked는 세션이 동시 세션 수 제한으로 종료되어 폐기 키가 남아 있는지 확인합니다.
+func (r *tokenRepository) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	n, err := r.client.Exists(ctx, revokedSessionPrefix+sessionID).Result()
+	if err != nil {
+		return false, err
 	}
+	return n > 0, nil
+}
 
-	return nil
+// CountActiveSessions는 저장된(만료되지 않은) 리프레시 토큰 수를 셉니다.
+// KEYS 대신 SCAN을 사용하므로 Redis를 오래 막지 않습니다.
+func (r *tokenRepository) CountActiveSessions(ctx context.Context) (int64, error) {
+	var (
+		count  int64
+		cursor uint64
+	)
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, "refresh_token:*", 1000).Result()
+		if err != nil {
+			return 0, err
+		}
+		count += int64(len(keys))
+		if next == 0 {
+			return count, nil
+		}
+		cursor = next
+	}
 }
@@ -0,0 +1,368 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+	"github.com/go-redis/redis/v8"
+)
+
+const testUser = "user-1"
+
+func newTestTokenRepo(t *testing.T) (*tokenRepository, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return NewTokenRepository(rdb), mr
+}
+
+func refreshKey(tokenID string) string {
+	return "refresh_token:" + testUser + ":" + tokenID
+}
+
+// store는 createdAt에 로그인한 세션의 리프레시 토큰을 한 시간 만료로 저장합니다.
+func store(t *testing.T, repo *tokenRepository, tokenID string, createdAt time.Time, limit tokenRepo.SessionCap) []string {
+	t.Helper()
+	evicted, err := repo.StoreRefreshToken(context.Background(), testUser, tokenID, "sid-"+tokenID, createdAt, createdAt.Add(time.Hour), limit)
+	if err != nil {
+		t.Fatalf("StoreRefreshToken(%s): %v", tokenID, err)
+	}
+	return evicted
+}
+
+// indexed는 세션 인덱스의 토큰 ID를 마지막 활동 순으로 반환합니다.
+func indexed(t *testing.T, mr *miniredis.Miniredis) []string {
+	t.Helper()
+	if !mr.Exists(sessionIndexKey(testUser)) {
+		return nil
+	}
+	ids, err := mr.ZMembers(sessionIndexKey(testUser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+// touch는 토큰 갱신처럼 세션의 마지막 활동 시각을 at으로 옮깁니다.
+func touch(t *testing.T, mr *miniredis.Miniredis, tokenID string, at time.Time) {
+	t.Helper()
+	mr.HSet(refreshKey(tokenID), "last_active", strconv.FormatInt(at.UnixMilli(), 10))
+	if _, err := mr.ZAdd(sessionIndexKey(testUser), float64(at.UnixMilli()), tokenID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStoreRefreshTokenEvictionPolicies(t *testing.T) {
+	tests := []struct {
+		policy  string
+		evicted string
+	}{
+		// a가 먼저 로그인했지만 최근에 갱신했으므로 evict_lru는 b를 종료
+		{tokenRepo.LimitEvictOldest, "a"},
+		{tokenRepo.LimitEvictLRU, "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			repo, mr := newTestTokenRepo(t)
+			t0 := time.Now()
+			limit := tokenRepo.SessionCap{Max: 2, Policy: tt.policy, RevokeFor: 15 * time.Minute}
+
+			store(t, repo, "a", t0, limit)
+			store(t, repo, "b", t0.Add(time.Second), limit)
+			touch(t, mr, "a", t0.Add(2*time.Second))
+
+			evicted := store(t, repo, "c", t0.Add(3*time.Second), limit)
+			if !slices.Equal(evicted, []string{tt.evicted}) {
+				t.Fatalf("evicted = %v, want [%s]", evicted, tt.evicted)
+			}
+			if mr.Exists(refreshKey(tt.evicted)) {
+				t.Fatal("evicted refresh token still stored")
+			}
+			if got := indexed(t, mr); len(got) != 2 || slices.Contains(got, tt.evicted) || !slices.Contains(got, "c") {
+				t.Fatalf("session index = %v", got)
+			}
+
+			// 종료한 세션만 폐기 목록에 남고, 폐기 기간 뒤에는 사라짐
+			ctx := context.Background()
+			for id, want := range map[string]bool{"a": tt.evicted == "a", "b": tt.evicted == "b", "c": false} {
+				revoked, err := repo.IsSessionRevoked(ctx, "sid-"+id)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if revoked != want {
+					t.Fatalf("IsSessionRevoked(sid-%s) = %v, want %v", id, revoked, want)
+				}
+			}
+			if ttl := mr.TTL(revokedSessionPrefix + "sid-" + tt.evicted); ttl != 15*time.Minute {
+				t.Fatalf("revocation ttl = %v, want 15m", ttl)
+			}
+			mr.FastForward(15 * time.Minute)
+			if revoked, _ := repo.IsSessionRevoked(ctx, "sid-"+tt.evicted); revoked {
+				t.Fatal("revocation outlived RevokeFor")
+			}
+		})
+	}
+}
+
+func TestStoreRefreshTokenEvictsDownToLimit(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	t0 := time.Now()
+	unlimited := tokenRepo.SessionCap{Policy: tokenRepo.LimitEvictOldest}
+	for i, id := range []string{"a", "b", "c"} {
+		store(t, repo, id, t0.Add(time.Duration(i)*time.Second), unlimited)
+	}
+
+	// 제한을 줄이면 다음 로그인에서 한도 아래로 맞출 만큼 종료
+	evicted := store(t, repo, "d", t0.Add(3*time.Second), tokenRepo.SessionCap{Max: 2, Policy: tokenRepo.LimitEvictOldest})
+	if !slices.Equal(evicted, []string{"a", "b"}) {
+		t.Fatalf("evicted = %v, want [a b]", evicted)
+	}
+	if got := indexed(t, mr); !slices.Equal(got, []string{"c", "d"}) {
+		t.Fatalf("session index = %v, want [c d]", got)
+	}
+}
+
+func TestStoreRefreshTokenReject(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	t0 := time.Now()
+	limit := tokenRepo.SessionCap{Max: 1, Policy: tokenRepo.LimitReject, RevokeFor: time.Minute}
+	store(t, repo, "a", t0, limit)
+
+	_, err := repo.StoreRefreshToken(context.Background(), testUser, "b", "sid-b", t0, t0.Add(time.Hour), limit)
+	if !errors.Is(err, tokenRepo.ErrSessionLimit) {
+		t.Fatalf("StoreRefreshToken = %v, want ErrSessionLimit", err)
+	}
+	if mr.Exists(refreshKey("b")) || !mr.Exists(refreshKey("a")) {
+		t.Fatal("rejected login changed the stored sessions")
+	}
+	if got := indexed(t, mr); !slices.Equal(got, []string{"a"}) {
+		t.Fatalf("session index = %v, want [a]", got)
+	}
+	if revoked, _ := repo.IsSessionRevoked(context.Background(), "sid-a"); revoked {
+		t.Fatal("rejected login revoked an existing session")
+	}
+}
+
+func TestStoreRefreshTokenPrunesExpiredIndexEntries(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	t0 := time.Now()
+	limit := tokenRepo.SessionCap{Max: 2, Policy: tokenRepo.LimitReject}
+
+	// a는 1분 뒤 만료, b는 한 시간 뒤 만료
+	if _, err := repo.StoreRefreshToken(context.Background(), testUser, "a", "sid-a", t0, t0.Add(time.Minute), limit); err != nil {
+		t.Fatal(err)
+	}
+	store(t, repo, "b", t0, limit)
+	mr.FastForward(2 * time.Minute)
+
+	// 만료된 a는 세지 않으므로 최대치(2)에 도달하지 않음
+	if evicted := store(t, repo, "c", t0.Add(2*time.Minute), limit); len(evicted) != 0 {
+		t.Fatalf("evicted = %v", evicted)
+	}
+	if got := indexed(t, mr); !slices.Equal(got, []string{"b", "c"}) {
+		t.Fatalf("session index = %v, want expired entry pruned", got)
+	}
+}
+
+func TestStoreRefreshTokenIgnoresLegacyStringKeys(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	t0 := time.Now()
+	limit := tokenRepo.SessionCap{Max: 1, Policy: tokenRepo.LimitEvictOldest, RevokeFor: time.Minute}
+
+	// 인덱스 도입 전에 저장된 토큰은 문자열이며 인덱스에 없음
+	if err := mr.Set(refreshKey("legacy"), "valid"); err != nil {
+		t.Fatal(err)
+	}
+	if evicted := store(t, repo, "a", t0, limit); len(evicted) != 0 {
+		t.Fatalf("legacy token counted against the limit: evicted %v", evicted)
+	}
+	if evicted := store(t, repo, "b", t0.Add(time.Second), limit); !slices.Equal(evicted, []string{"a"}) {
+		t.Fatalf("evicted = %v, want [a]", evicted)
+	}
+	if v, err := mr.Get(refreshKey("legacy")); err != nil || v != "valid" {
+		t.Fatalf("legacy token = %q, %v; want it left untouched", v, err)
+	}
+}
+
+// seedSession은 createdAt에 로그인해 lastActive에 마지막으로 갱신한 세션을 저장합니다.
+func seedSession(t *testing.T, mr *miniredis.Miniredis, tokenID string, createdAt, lastActive time.Time) {
+	t.Helper()
+	mr.HSet(refreshKey(tokenID), "created_at", strconv.FormatInt(createdAt.UnixMilli(), 10))
+	touch(t, mr, tokenID, lastActive)
+}
+
+func rotate(repo *tokenRepository, oldID, newID string, limits tokenRepo.SessionLimits, fallbackCreatedAt time.Time) error {
+	return repo.RotateRefreshToken(context.Background(), testUser, oldID, newID, "sid-1", limits, fallbackCreatedAt, time.Now().Add(time.Hour))
+}
+
+func hashInt(t *testing.T, mr *miniredis.Miniredis, key, field string) int64 {
+	t.Helper()
+	n, err := strconv.ParseInt(mr.HGet(key, field), 10, 64)
+	if err != nil {
+		t.Fatalf("%s %s: %v", key, field, err)
+	}
+	return n
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	createdAt := time.Now().Add(-30 * time.Minute)
+	lastActive := time.Now().Add(-5 * time.Minute)
+	seedSession(t, mr, "old", createdAt, lastActive)
+	limits := tokenRepo.SessionLimits{Lifetime: time.Hour, IdleTimeout: 10 * time.Minute}
+
+	before := time.Now()
+	if err := rotate(repo, "old", "new", limits, time.Time{}); err != nil {
+		t.Fatalf("RotateRefreshToken = %v", err)
+	}
+
+	if mr.Exists(refreshKey("old")) {
+		t.Fatal("old refresh token still stored")
+	}
+	key := refreshKey("new")
+	if got := hashInt(t, mr, key, "created_at"); got != createdAt.UnixMilli() {
+		t.Fatalf("created_at = %d, want session start %d", got, createdAt.UnixMilli())
+	}
+	active := hashInt(t, mr, key, "last_active")
+	if active < before.UnixMilli() {
+		t.Fatalf("last_active = %d, want moved forward to at least %d", active, before.UnixMilli())
+	}
+	if sid := mr.HGet(key, "session_id"); sid != "sid-1" {
+		t.Fatalf("session_id = %q", sid)
+	}
+	if mr.TTL(key) <= 0 {
+		t.Fatal("new refresh token has no expiry")
+	}
+
+	if got := indexed(t, mr); !slices.Equal(got, []string{"new"}) {
+		t.Fatalf("session index = %v, want [new]", got)
+	}
+	score, err := mr.ZScore(sessionIndexKey(testUser), "new")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(score) != active {
+		t.Fatalf("index score = %d, want last_active %d", int64(score), active)
+	}
+}
+
+func TestRotateRefreshTokenLimits(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name       string
+		createdAt  time.Time
+		lastActive time.Time
+		want       error
+	}{
+		{"lifetime exceeded", now.Add(-2 * time.Hour), now.Add(-time.Minute), tokenRepo.ErrSessionExpired},
+		{"idle exceeded", now.Add(-30 * time.Minute), now.Add(-20 * time.Minute), tokenRepo.ErrSessionIdle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mr := newTestTokenRepo(t)
+			seedSession(t, mr, "old", tt.createdAt, tt.lastActive)
+			limits := tokenRepo.SessionLimits{Lifetime: time.Hour, IdleTimeout: 10 * time.Minute}
+
+			if err := rotate(repo, "old", "new", limits, time.Time{}); !errors.Is(err, tt.want) {
+				t.Fatalf("RotateRefreshToken = %v, want %v", err, tt.want)
+			}
+			// 제한을 넘은 세션은 삭제되고 새 토큰은 저장되지 않음
+			if mr.Exists(refreshKey("old")) || mr.Exists(refreshKey("new")) {
+				t.Fatal("expired session left refresh tokens behind")
+			}
+			if got := indexed(t, mr); len(got) != 0 {
+				t.Fatalf("session index = %v, want empty", got)
+			}
+		})
+	}
+}
+
+func TestRotateRefreshTokenOnlyOnce(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	seedSession(t, mr, "old", time.Now(), time.Now())
+
+	if err := rotate(repo, "old", "first", tokenRepo.SessionLimits{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	// 같은 토큰으로 두 번째 갱신 (동시 요청 또는 재사용)
+	if err := rotate(repo, "old", "second", tokenRepo.SessionLimits{}, time.Time{}); !errors.Is(err, tokenRepo.ErrTokenNotFound) {
+		t.Fatalf("second rotation = %v, want ErrTokenNotFound", err)
+	}
+	if mr.Exists(refreshKey("second")) {
+		t.Fatal("second rotation stored a token")
+	}
+	if got := indexed(t, mr); !slices.Equal(got, []string{"first"}) {
+		t.Fatalf("session index = %v, want [first]", got)
+	}
+}
+
+func TestRotateRefreshTokenLegacyStringKey(t *testing.T) {
+	limits := tokenRepo.SessionLimits{Lifetime: time.Hour}
+	tests := []struct {
+		name     string
+		fallback time.Time
+		want     error
+	}{
+		{"within lifetime", time.Now().Add(-10 * time.Minute), nil},
+		{"lifetime exceeded", time.Now().Add(-2 * time.Hour), tokenRepo.ErrSessionExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, mr := newTestTokenRepo(t)
+			if err := mr.Set(refreshKey("legacy"), "valid"); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := rotate(repo, "legacy", "new", limits, tt.fallback); !errors.Is(err, tt.want) {
+				t.Fatalf("RotateRefreshToken = %v, want %v", err, tt.want)
+			}
+			if mr.Exists(refreshKey("legacy")) {
+				t.Fatal("legacy token still stored")
+			}
+			if tt.want != nil {
+				return
+			}
+			// 생성 시각이 없는 토큰은 fallbackCreatedAt을 세션 시작으로 이어받음
+			if got := hashInt(t, mr, refreshKey("new"), "created_at"); got != tt.fallback.UnixMilli() {
+				t.Fatalf("created_at = %d, want fallback %d", got, tt.fallback.UnixMilli())
+			}
+		})
+	}
+}
+
+func TestRotateRefreshTokenConcurrent(t *testing.T) {
+	repo, mr := newTestTokenRepo(t)
+	seedSession(t, mr, "old", time.Now(), time.Now())
+
+	const n = 8
+	errs := make(chan error, n)
+	for i := range n {
+		go func() {
+			errs <- rotate(repo, "old", "new-"+strconv.Itoa(i), tokenRepo.SessionLimits{}, time.Time{})
+		}()
+	}
+	var ok, notFound int
+	for range n {
+		switch err := <-errs; {
+		case err == nil:
+			ok++
+		case errors.Is(err, tokenRepo.ErrTokenNotFound):
+			notFound++
+		default:
+			t.Fatalf("RotateRefreshToken = %v", err)
+		}
+	}
+	if ok != 1 || notFound != n-1 {
+		t.Fatalf("successful rotations = %d, not found = %d; want exactly one success", ok, notFound)
+	}
+	if got := indexed(t, mr); len(got) != 1 {
+		t.Fatalf("session index = %v, want one session", got)
+	}
+}
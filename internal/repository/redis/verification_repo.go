@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/aquaheyday/go-auth-service/internal/domain"
 	"github.com/go-redis/redis/v8"
 )
 
@@ -18,7 +19,7 @@ func NewVerificationRepository(rdb *redis.Client) *VerificationRepository {
 
 func (r *VerificationRepository) SaveCode(ctx context.Context, email, code string) error {
 	key := "verify:" + email
-	return r.rdb.Set(ctx, key, code, 10*time.Minute).Err()
+	return r.rdb.Set(ctx, key, code, domain.EmailCodeTTL).Err()
 }
 
 func (r *VerificationRepository) VerifyCode(ctx context.Context, email, code string) (bool, error) {
@@ -33,9 +34,14 @@ func (r *VerificationRepository) VerifyCode(ctx context.Context, email, code str
 }
 
 // GetCode retrieves the raw verification code.
+// Returns domain.ErrNotFound if the code does not exist or has expired.
 func (r *VerificationRepository) GetCode(ctx context.Context, email string) (string, error) {
 	key := "verify:" + email
-	return r.rdb.Get(ctx, key).Result()
+	code, err := r.rdb.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", domain.ErrNotFound
+	}
+	return code, err
 }
 
 // DeleteCode removes the verification code after use.
@@ -47,17 +53,21 @@ func (r *VerificationRepository) DeleteCode(ctx context.Context, email string) e
 // StorePhoneVerificationCode 휴대폰 인증 코드를 저장합니다
 func (r *VerificationRepository) StorePhoneVerificationCode(ctx context.Context, phoneNumber, code string, expiration time.Duration) error {
 	key := fmt.Sprintf("phone_verification:%s", phoneNumber)
-	return r.client.Set(ctx, key, code, expiration)
+	return r.rdb.Set(ctx, key, code, expiration).Err()
 }
 
-// GetPhoneVerificationCode 저장된 휴대폰 인증 코드를 조회합니다
-func (r *verificationRepository) GetPhoneVerificationCode(ctx context.Context, phoneNumber string) (string, error) {
+// GetPhoneVerificationCode 저장된 휴대폰 인증 코드를 조회합니다 (없거나 만료되면 domain.ErrNotFound)
+func (r *VerificationRepository) GetPhoneVerificationCode(ctx context.Context, phoneNumber string) (string, error) {
 	key := fmt.Sprintf("phone_verification:%s", phoneNumber)
-	return r.client.Get(ctx, key)
+	code, err := r.rdb.Get(ctx, key).Result()
+	if err == redis.Nil {
+		return "", domain.ErrNotFound
+	}
+	return code, err
 }
 
 // DeletePhoneVerificationCode 휴대폰 인증 코드를 삭제합니다
-func (r *verificationRepository) DeletePhoneVerificationCode(ctx context.Context, phoneNumber string) error {
+func (r *VerificationRepository) DeletePhoneVerificationCode(ctx context.Context, phoneNumber string) error {
 	key := fmt.Sprintf("phone_verification:%s", phoneNumber)
-	return r.client.Del(ctx, key)
+	return r.rdb.Del(ctx, key).Err()
 }
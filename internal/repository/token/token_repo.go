@@ -4,12 +4,54 @@ package token
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// RotateRefreshToken이 토큰을 회전하지 않은 이유
+var (
+	ErrTokenNotFound  = errors.New("refresh token not found")       // 이미 회전되었거나 로그아웃된 토큰
+	ErrSessionExpired = errors.New("session lifetime exceeded")     // 로그인 시각부터 SessionLimits.Lifetime 경과
+	ErrSessionIdle    = errors.New("session idle timeout exceeded") // 마지막 갱신부터 SessionLimits.IdleTimeout 경과
+)
+
+// ErrSessionLimit은 SessionCap.Policy가 LimitReject이고 사용자의 세션 수가 최대치에 도달했을 때 반환됩니다.
+var ErrSessionLimit = errors.New("session limit reached")
+
+// 사용자당 세션 수가 최대치에 도달했을 때의 처리 방식
+const (
+	LimitReject      = "reject"       // 새 로그인 거부
+	LimitEvictOldest = "evict_oldest" // 가장 먼저 로그인한 세션 종료
+	LimitEvictLRU    = "evict_lru"    // 가장 오래 갱신하지 않은 세션 종료
+)
+
+// SessionCap은 사용자당 동시 세션 수 제한입니다. Max가 0이면 제한하지 않습니다.
+// 세션 종료는 리프레시 토큰 삭제와 세션 폐기이며, 폐기된 세션의 액세스 토큰은 만료 전이라도 거부됩니다.
+type SessionCap struct {
+	Max       int
+	Policy    string        // LimitReject, LimitEvictOldest, LimitEvictLRU
+	RevokeFor time.Duration // 종료한 세션을 폐기 목록에 유지하는 기간 (가장 긴 액세스 토큰 유효 기간)
+}
+
+// SessionLimits는 토큰 회전 시 검사하는 세션 제한입니다. 0이면 제한하지 않습니다.
+type SessionLimits struct {
+	Lifetime    time.Duration // 세션 생성 시각부터 최대 수명
+	IdleTimeout time.Duration // 마지막 활동(로그인 또는 갱신)부터 최대 유휴 시간
+}
+
 type Repository interface {
-	StoreRefreshToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error
+	// StoreRefreshToken은 createdAt에 시작한 새 세션(sessionID)의 리프레시 토큰을 저장합니다.
+	// 사용자의 세션 수가 limit.Max에 도달했으면 limit.Policy에 따라 ErrSessionLimit을 반환하거나,
+	// 기존 세션을 종료(리프레시 토큰 삭제, limit.RevokeFor 동안 세션 폐기)하고 종료한 세션의 토큰 ID를 반환합니다.
+	// 검사와 저장은 원자적입니다.
+	StoreRefreshToken(ctx context.Context, userID, tokenID, sessionID string, createdAt, expiresAt time.Time, limit SessionCap) (evicted []string, err error)
 	ValidateRefreshToken(ctx context.Context, userID, tokenID string) (bool, error)
+	// RotateRefreshToken은 oldTokenID를 newTokenID로 원자적으로 교체합니다.
+	// 세션 생성 시각은 이어받고 마지막 활동 시각은 현재로 갱신하며, limits를 넘은 세션은 삭제하고 ErrSessionExpired 또는 ErrSessionIdle을 반환합니다.
+	// 세션 생성 시각이 기록되지 않은 토큰은 fallbackCreatedAt을 생성 시각으로 봅니다. 새 토큰은 sessionID 세션에 속합니다.
+	RotateRefreshToken(ctx context.Context, userID, oldTokenID, newTokenID, sessionID string, limits SessionLimits, fallbackCreatedAt, expiresAt time.Time) error
 	DeleteRefreshToken(ctx context.Context, userID, tokenID string) error
 	DeleteAllUserTokens(ctx context.Context, userID string) error
+	// IsSessionRevoked는 세션이 동시 세션 수 제한으로 종료되어 폐기되었는지 확인합니다.
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
 }
@@ -7,112 +7,187 @@ import (
 	"errors"
 	"time"
 
-	"github.com/aquaheyday/go-auth-service/internal/repository/postgres"
+	"github.com/aquaheyday/go-auth-service/internal/domain"
 	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
 	"github.com/aquaheyday/go-auth-service/pkg/token"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"go.uber.org/zap"
 )
 
 type LoginUseCase interface {
-	Login(ctx context.Context, email, password string) (string, string, string, error)
+	Login(ctx context.Context, email, password, clientID, clientSecret string) (string, string, string, error)
 	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
 	Logout(ctx context.Context, refreshToken string) error
 }
 
 type loginUseCase struct {
-	userRepo  postgres.UserRepository
+	userRepo  UserRepository
 	tokenRepo tokenRepo.Repository
+	tokens    *token.Issuer
+	settings  *Settings
 }
 
-func NewLoginUseCase(userRepo postgres.UserRepository, tokenRepo tokenRepo.Repository) LoginUseCase {
+func NewLoginUseCase(userRepo UserRepository, tokenRepo tokenRepo.Repository, tokens *token.Issuer, settings *Settings) LoginUseCase {
 	return &loginUseCase{
 		userRepo:  userRepo,
 		tokenRepo: tokenRepo,
+		tokens:    tokens,
+		settings:  settings,
 	}
 }
 
-// 로그인 처리
-func (uc *loginUseCase) Login(ctx context.Context, email, password string) (string, string, string, error) {
+// 로그인 처리 - clientID의 토큰 정책으로 토큰 발급 (비어 있으면 기본 정책)
+// clientID를 지정하면 clientSecret으로 클라이언트를 인증해야 하며, 등록되지 않은 클라이언트는 거부합니다.
+func (uc *loginUseCase) Login(ctx context.Context, email, password, clientID, clientSecret string) (_, _, _ string, err error) {
+	ctx, span := startSpan(ctx, "LoginUseCase.Login")
+	outcome := loginError
+	defer func() {
+		// 계정 잠금은 저장소 등 어느 단계에서 반환되더라도 같은 결과로 집계
+		if errors.Is(err, domain.ErrLocked) {
+			outcome = loginLocked
+		}
+		loginsTotal.WithLabelValues(outcome).Inc()
+		tracing.End(span, err)
+	}()
+
+	if err := uc.tokens.AuthenticateClient(clientID, clientSecret); err != nil {
+		outcome = loginInvalidClient
+		logger.FromContext(ctx).Warn("login rejected: client authentication failed", zap.String("client_id", clientID))
+		return "", "", "", domain.ErrInvalidClient
+	}
+
 	user, err := uc.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		// 가입 여부를 노출하지 않도록 비밀번호 불일치와 같은 에러 반환
+		// 응답 시간으로도 구분되지 않도록 가입된 사용자와 같은 비용의 bcrypt 비교를 수행
+		_ = comparePassword(ctx, dummyPasswordHash, password)
+		outcome = loginUnknownUser
+		return "", "", "", domain.ErrInvalidCredentials
+	}
 	if err != nil {
 		return "", "", "", err
 	}
 
 	// 비밀번호 확인
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", "", "", errors.New("invalid credentials")
+	if err := comparePassword(ctx, user.PasswordHash, password); err != nil {
+		outcome = loginBadPassword
+		return "", "", "", domain.ErrInvalidCredentials
 	}
 
-	// 액세스 토큰 생성
-	accessToken, err := token.GenerateAccessToken(user.ID)
+	// 새 세션의 토큰 발급
+	sessionID, err := token.NewSessionID()
 	if err != nil {
 		return "", "", "", err
 	}
-
-	// 리프레시 토큰 생성
-	refreshToken, tokenID, err := token.GenerateRefreshToken(user.ID)
+	session := token.Session{ID: sessionID, UserID: user.ID, ClientID: clientID, AuthTime: time.Now()}
+	access, refresh, err := uc.generateTokens(session)
 	if err != nil {
 		return "", "", "", err
 	}
 
-	// 리프레시 토큰을 Redis에 저장
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	if err := uc.tokenRepo.StoreRefreshToken(ctx, user.ID, tokenID, expiresAt); err != nil {
+	// 리프레시 토큰을 세션 생성 시각과 함께 Redis에 저장 (동시 세션 수 제한 적용)
+	// 종료한 세션은 이미 발급된 액세스 토큰이 모두 만료될 때까지 폐기 목록에 남김
+	limit := uc.settings.SessionCap()
+	limit.RevokeFor = uc.tokens.MaxAccessTTL()
+	evicted, err := uc.tokenRepo.StoreRefreshToken(ctx, user.ID, refresh.ID, session.ID, session.AuthTime, refresh.ExpiresAt, limit)
+	if errors.Is(err, tokenRepo.ErrSessionLimit) {
+		outcome = loginSessionCap
+		return "", "", "", domain.ErrSessionLimit
+	}
+	if err != nil {
 		return "", "", "", err
 	}
+	if len(evicted) > 0 {
+		// 종료된 세션은 리프레시 토큰이 삭제되어 더 이상 갱신할 수 없고, 액세스 토큰은 검증 시 거부됨
+		sessionsEvictedTotal.WithLabelValues(limit.Policy).Add(float64(len(evicted)))
+		logger.FromContext(ctx).Info("sessions evicted by concurrent session limit",
+			zap.String("user_id", user.ID), zap.String("policy", limit.Policy), zap.Strings("token_ids", evicted))
+	}
 
-	return user.ID, accessToken, refreshToken, nil
+	outcome = loginSuccess
+	return user.ID, access.Token, refresh.Token, nil
 }
 
 // 토큰 갱신
-func (uc *loginUseCase) RefreshToken(ctx context.Context, refreshTokenStr string) (string, string, error) {
+func (uc *loginUseCase) RefreshToken(ctx context.Context, refreshTokenStr string) (_, _ string, err error) {
+	ctx, span := startSpan(ctx, "LoginUseCase.RefreshToken")
+	outcome := refreshError
+	defer func() {
+		tokenRefreshesTotal.WithLabelValues(outcome).Inc()
+		tracing.End(span, err)
+	}()
+
 	// 리프레시 토큰 검증
-	claims, err := token.ValidateRefreshToken(refreshTokenStr)
+	claims, err := uc.tokens.ValidateRefreshToken(refreshTokenStr)
 	if err != nil {
-		return "", "", err
+		outcome = refreshInvalid
+		return "", "", domain.ErrInvalidToken
 	}
 
-	// Redis에서 토큰 확인
-	valid, err := uc.tokenRepo.ValidateRefreshToken(ctx, claims.UserID, claims.TokenID)
+	// 같은 세션(세션 ID, 클라이언트, 로그인 시각 유지)으로 새 토큰 발급
+	// 세션 ID 도입 전에 발급된 토큰은 이번 회전부터 새 세션 ID를 사용
+	session := token.SessionFromClaims(claims)
+	if session.ID == "" {
+		if session.ID, err = token.NewSessionID(); err != nil {
+			return "", "", err
+		}
+	}
+	access, refresh, err := uc.generateTokens(session)
 	if err != nil {
 		return "", "", err
 	}
-	if !valid {
-		return "", "", errors.New("invalid or expired refresh token")
-	}
 
-	// 기존 토큰 삭제
-	if err := uc.tokenRepo.DeleteRefreshToken(ctx, claims.UserID, claims.TokenID); err != nil {
+	// 기존 토큰을 새 토큰으로 교체 (토큰 회전) - 세션 수명과 유휴 시간 검사, 교체가 하나의 원자적 연산
+	// 제한은 현재 정책을 따르므로 설정에서 줄이면 이미 로그인한 세션에도 적용
+	policy := uc.tokens.PolicyFor(session.ClientID)
+	limits := tokenRepo.SessionLimits{Lifetime: policy.SessionLifetime, IdleTimeout: policy.IdleTimeout}
+	err = uc.tokenRepo.RotateRefreshToken(ctx, claims.UserID, claims.TokenID, refresh.ID, session.ID, limits, session.AuthTime, refresh.ExpiresAt)
+	switch {
+	case errors.Is(err, tokenRepo.ErrTokenNotFound):
+		// 서명과 만료 시각은 유효한데 저장소에 없음 → 이미 회전되었거나 로그아웃된 토큰의 재사용
+		outcome = refreshReused
+		logger.FromContext(ctx).Warn("refresh token reuse detected",
+			zap.String("user_id", claims.UserID), zap.String("token_id", claims.TokenID))
+		return "", "", domain.ErrInvalidToken
+	case errors.Is(err, tokenRepo.ErrSessionExpired):
+		outcome = refreshExpired
+		return "", "", domain.ErrSessionExpired
+	case errors.Is(err, tokenRepo.ErrSessionIdle):
+		outcome = refreshIdle
+		return "", "", domain.ErrSessionExpired
+	case err != nil:
 		return "", "", err
 	}
 
-	// 새 액세스 토큰 생성
-	accessToken, err := token.GenerateAccessToken(claims.UserID)
-	if err != nil {
-		return "", "", err
-	}
+	outcome = refreshRotated
+	return access.Token, refresh.Token, nil
+}
 
-	// 새 리프레시 토큰 생성 (토큰 회전)
-	newRefreshToken, newTokenID, err := token.GenerateRefreshToken(claims.UserID)
+// generateTokens는 세션의 액세스/리프레시 토큰을 발급합니다.
+// 만료 시각은 클라이언트 정책에 따라 발급 시 정해지며, 저장소에는 그 값을 그대로 저장합니다.
+func (uc *loginUseCase) generateTokens(s token.Session) (access, refresh token.Issued, err error) {
+	access, err = uc.tokens.GenerateAccessToken(s)
 	if err != nil {
-		return "", "", err
+		return token.Issued{}, token.Issued{}, err
 	}
 
-	// 새 리프레시 토큰 저장
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
-	if err := uc.tokenRepo.StoreRefreshToken(ctx, claims.UserID, newTokenID, expiresAt); err != nil {
-		return "", "", err
+	refresh, err = uc.tokens.GenerateRefreshToken(s)
+	if err != nil {
+		return token.Issued{}, token.Issued{}, err
 	}
-
-	return accessToken, newRefreshToken, nil
+	return access, refresh, nil
 }
 
 // 로그아웃
-func (uc *loginUseCase) Logout(ctx context.Context, refreshTokenStr string) error {
+func (uc *loginUseCase) Logout(ctx context.Context, refreshTokenStr string) (err error) {
+	ctx, span := startSpan(ctx, "LoginUseCase.Logout")
+	defer func() { tracing.End(span, err) }()
+
 	// 리프레시 토큰 검증
-	claims, err := token.ValidateRefreshToken(refreshTokenStr)
+	claims, err := uc.tokens.ValidateRefreshToken(refreshTokenStr)
 	if err != nil {
-		return err
+		return domain.ErrInvalidToken
 	}
 
 	// Redis에서 토큰 삭제
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+	"github.com/aquaheyday/go-auth-service/pkg/token"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fakeUserRepo는 이메일로 조회할 수 있는 사용자 목록입니다. err이 있으면 조회 시 반환합니다.
+type fakeUserRepo struct {
+	users map[string]*domain.User
+	err   error
+}
+
+func (r *fakeUserRepo) Create(context.Context, *domain.User) (string, error) { return "", nil }
+func (r *fakeUserRepo) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if u, ok := r.users[email]; ok {
+		return u, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+// fakeTokenRepo는 저장 요청에 적용된 세션 수 제한을 기록하고 정해진 결과를 반환합니다.
+type fakeTokenRepo struct {
+	tokenRepo.Repository
+	limits    []tokenRepo.SessionCap
+	storeErr  error
+	rotateErr error
+}
+
+func (r *fakeTokenRepo) StoreRefreshToken(_ context.Context, _, _, _ string, _, _ time.Time, limit tokenRepo.SessionCap) ([]string, error) {
+	r.limits = append(r.limits, limit)
+	if r.storeErr != nil {
+		return nil, r.storeErr
+	}
+	return nil, nil
+}
+
+func (r *fakeTokenRepo) RotateRefreshToken(context.Context, string, string, string, string, tokenRepo.SessionLimits, time.Time, time.Time) error {
+	return r.rotateErr
+}
+
+func newTestIssuer() *token.Issuer {
+	return token.NewIssuer([]byte("access-secret"), []byte("refresh-secret"), token.Policies{
+		Default: token.Policy{AccessTTL: time.Minute, RefreshTTL: time.Hour},
+		Clients: map[string]token.Policy{"mobile": {Secret: "mobile-secret"}},
+	}, nil)
+}
+
+func newTestLogin(t *testing.T, settings *Settings) (LoginUseCase, *fakeTokenRepo) {
+	uc, _, tokens := newTestLoginWithUsers(t, settings)
+	return uc, tokens
+}
+
+func newTestLoginWithUsers(t *testing.T, settings *Settings) (LoginUseCase, *fakeUserRepo, *fakeTokenRepo) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := &fakeUserRepo{users: map[string]*domain.User{
+		"user@example.com": {ID: "user-1", Email: "user@example.com", PasswordHash: string(hash)},
+	}}
+	tokens := &fakeTokenRepo{}
+	return NewLoginUseCase(users, tokens, newTestIssuer(), settings), users, tokens
+}
+
+func TestLoginUsesInjectedSessionCap(t *testing.T) {
+	settings := NewSettings(Features{}, tokenRepo.SessionCap{Max: 3, Policy: tokenRepo.LimitEvictOldest})
+	uc, tokens := newTestLogin(t, settings)
+	ctx := context.Background()
+
+	if _, _, _, err := uc.Login(ctx, "user@example.com", "password123", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	settings.SetSessionCap(tokenRepo.SessionCap{Max: 1, Policy: tokenRepo.LimitReject})
+	tokens.storeErr = tokenRepo.ErrSessionLimit
+	_, _, _, err := uc.Login(ctx, "user@example.com", "password123", "", "")
+	if !errors.Is(err, domain.ErrSessionLimit) {
+		t.Fatalf("Login = %v, want ErrSessionLimit", err)
+	}
+
+	// 종료한 세션은 가장 긴 액세스 토큰 유효 기간 동안 폐기
+	want := []tokenRepo.SessionCap{
+		{Max: 3, Policy: tokenRepo.LimitEvictOldest, RevokeFor: time.Minute},
+		{Max: 1, Policy: tokenRepo.LimitReject, RevokeFor: time.Minute},
+	}
+	if len(tokens.limits) != 2 || tokens.limits[0] != want[0] || tokens.limits[1] != want[1] {
+		t.Fatalf("limits passed to the repository = %v, want %v", tokens.limits, want)
+	}
+}
+
+func TestSignupUsesInjectedFeatures(t *testing.T) {
+	settings := NewSettings(Features{Signup: false}, tokenRepo.SessionCap{})
+	uc := NewSignupUseCase(&fakeUserRepo{}, nil, settings)
+
+	if _, err := uc.SignUp(context.Background(), "user@example.com", "password123", "123456"); !errors.Is(err, domain.ErrFeatureDisabled) {
+		t.Fatalf("SignUp = %v, want ErrFeatureDisabled", err)
+	}
+}
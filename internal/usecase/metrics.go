@@ -0,0 +1,174 @@
+// internal/usecase/metrics.go
+// 이 파일은 인증 흐름의 비즈니스 지표(Prometheus)를 정의합니다.
+package usecase
+
+import (
+	"context"
+	"time"
+
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// 로그인 결과
+const (
+	loginSuccess       = "success"
+	loginBadPassword   = "bad_password"
+	loginUnknownUser   = "unknown_user"
+	loginInvalidClient = "invalid_client" // 클라이언트 인증 실패 (domain.ErrInvalidClient)
+	loginLocked        = "locked"         // 잠긴 계정 (domain.ErrLocked)
+	loginSessionCap    = "session_limit"  // 동시 세션 수 제한으로 거부 (domain.ErrSessionLimit)
+	loginError         = "error"
+)
+
+// 회원 가입 결과
+const (
+	signupSuccess       = "success"
+	signupInvalidCode   = "invalid_code"
+	signupAlreadyExists = "already_exists"
+	signupDisabled      = "disabled" // 회원 가입 기능 꺼짐
+	signupError         = "error"
+)
+
+// 리프레시 토큰 사용 결과
+const (
+	refreshRotated = "rotated"
+	refreshReused  = "reused"  // 서명은 유효하지만 저장소에 없는 토큰 (이미 회전되었거나 로그아웃된 토큰의 재사용)
+	refreshExpired = "expired" // 세션 최대 수명 초과
+	refreshIdle    = "idle"    // 세션 유휴 시간 초과
+	refreshInvalid = "invalid"
+	refreshError   = "error"
+)
+
+// 인증 코드 이벤트
+const (
+	codeSent     = "sent"
+	codeVerified = "verified"
+	codeMismatch = "mismatch"
+	codeExpired  = "expired" // 코드가 없음 (만료되었거나 발송되지 않음)
+)
+
+// 인증 코드 채널
+const (
+	channelEmail = "email"
+	channelSMS   = "sms"
+)
+
+var (
+	loginsTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_logins_total",
+			Help: "Total number of login attempts by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	signupsTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_signups_total",
+			Help: "Total number of signup attempts by outcome",
+		},
+		[]string{"outcome"},
+	)
+
+	verificationCodesTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_verification_codes_total",
+			Help: "Total number of verification code events (sent, verified, mismatch, expired) by channel",
+		},
+		[]string{"channel", "event"},
+	)
+
+	tokenRefreshesTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_token_refreshes_total",
+			Help: "Total number of refresh token uses by outcome (rotated, reused, expired, idle, invalid, error)",
+		},
+		[]string{"outcome"},
+	)
+
+	sessionsEvictedTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_sessions_evicted_total",
+			Help: "Total number of sessions ended and revoked to keep a user under the concurrent session limit, by policy (evict_oldest, evict_lru)",
+		},
+		[]string{"policy"},
+	)
+
+	activeSessions = metrics.Factory.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "auth_active_sessions",
+			Help: "Number of active sessions (unexpired refresh tokens) across all replicas",
+		},
+	)
+
+	smsProviderDuration = metrics.NewLatencyHistogram(
+		"sms_provider_send_duration_seconds",
+		"Latency of SMS provider send calls by provider and result",
+		"provider", "result",
+	)
+
+	smsProviderFailures = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sms_provider_failures_total",
+			Help: "Total number of failed SMS provider send calls by provider",
+		},
+		[]string{"provider"},
+	)
+)
+
+func init() {
+	// 아직 발생하지 않은 결과도 0으로 노출해 대시보드와 알림 규칙이 시계열 부재로 깨지지 않도록 함
+	for _, outcome := range []string{loginSuccess, loginBadPassword, loginUnknownUser, loginInvalidClient, loginLocked, loginSessionCap, loginError} {
+		loginsTotal.WithLabelValues(outcome)
+	}
+	for _, outcome := range []string{signupSuccess, signupInvalidCode, signupAlreadyExists, signupDisabled, signupError} {
+		signupsTotal.WithLabelValues(outcome)
+	}
+	for _, outcome := range []string{refreshRotated, refreshReused, refreshExpired, refreshIdle, refreshInvalid, refreshError} {
+		tokenRefreshesTotal.WithLabelValues(outcome)
+	}
+	for _, policy := range []string{tokenRepo.LimitEvictOldest, tokenRepo.LimitEvictLRU} {
+		sessionsEvictedTotal.WithLabelValues(policy)
+	}
+	for _, channel := range []string{channelEmail, channelSMS} {
+		for _, event := range []string{codeSent, codeVerified, codeMismatch, codeExpired} {
+			verificationCodesTotal.WithLabelValues(channel, event)
+		}
+	}
+}
+
+// SessionCounter는 활성 세션 수를 세는 저장소입니다.
+type SessionCounter interface {
+	CountActiveSessions(ctx context.Context) (int64, error)
+}
+
+// RunActiveSessionsGauge는 ctx가 취소될 때까지 interval마다 활성 세션 수를 auth_active_sessions에 반영합니다.
+// 모든 레플리카가 같은 저장소를 세므로 값은 레플리카 수와 관계없이 전체 세션 수입니다.
+func RunActiveSessionsGauge(ctx context.Context, counter SessionCounter, interval time.Duration, log *zap.Logger) {
+	update := func() {
+		n, err := counter.CountActiveSessions(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Ctx(ctx, log).Warn("failed to count active sessions", zap.Error(err))
+			}
+			return
+		}
+		activeSessions.Set(float64(n))
+	}
+
+	update()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			update()
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+	"github.com/aquaheyday/go-auth-service/pkg/token"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// counting은 fn 실행 전후 counter의 증가량을 반환합니다 (카운터는 패키지 전역이라 절대값 대신 차이를 비교).
+func counting(counter prometheus.Counter, fn func()) float64 {
+	before := testutil.ToFloat64(counter)
+	fn()
+	return testutil.ToFloat64(counter) - before
+}
+
+func TestLoginOutcomeMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		password string
+		clientID string
+		userErr  error
+		storeErr error
+		want     string
+	}{
+		{"success", "user@example.com", "password123", "", nil, nil, loginSuccess},
+		{"bad password", "user@example.com", "wrong-password", "", nil, nil, loginBadPassword},
+		{"unknown user", "nobody@example.com", "password123", "", nil, nil, loginUnknownUser},
+		{"invalid client", "user@example.com", "password123", "unknown-client", nil, nil, loginInvalidClient},
+		{"locked account", "user@example.com", "password123", "", domain.ErrLocked, nil, loginLocked},
+		{"session limit", "user@example.com", "password123", "", nil, tokenRepo.ErrSessionLimit, loginSessionCap},
+		{"repository error", "user@example.com", "password123", "", errors.New("connection refused"), nil, loginError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, users, tokens := newTestLoginWithUsers(t, NewSettings(Features{}, tokenRepo.SessionCap{}))
+			users.err = tt.userErr
+			tokens.storeErr = tt.storeErr
+
+			var err error
+			got := counting(loginsTotal.WithLabelValues(tt.want), func() {
+				_, _, _, err = uc.Login(context.Background(), tt.email, tt.password, tt.clientID, "")
+			})
+			if got != 1 {
+				t.Fatalf("auth_logins_total{outcome=%q} += %v, want 1 (err %v)", tt.want, got, err)
+			}
+			if (tt.want == loginSuccess) != (err == nil) {
+				t.Fatalf("Login err = %v", err)
+			}
+		})
+	}
+}
+
+func TestRefreshOutcomeMetrics(t *testing.T) {
+	refresh, err := newTestIssuer().GenerateRefreshToken(token.Session{ID: "s1", UserID: "user-1", AuthTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		token     string
+		rotateErr error
+		want      string
+	}{
+		{"rotated", refresh.Token, nil, refreshRotated},
+		{"reused", refresh.Token, tokenRepo.ErrTokenNotFound, refreshReused},
+		{"expired", refresh.Token, tokenRepo.ErrSessionExpired, refreshExpired},
+		{"idle", refresh.Token, tokenRepo.ErrSessionIdle, refreshIdle},
+		{"invalid", "not-a-token", nil, refreshInvalid},
+		{"repository error", refresh.Token, errors.New("connection refused"), refreshError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, tokens := newTestLogin(t, NewSettings(Features{}, tokenRepo.SessionCap{}))
+			tokens.rotateErr = tt.rotateErr
+
+			got := counting(tokenRefreshesTotal.WithLabelValues(tt.want), func() {
+				_, _, _ = uc.RefreshToken(context.Background(), tt.token)
+			})
+			if got != 1 {
+				t.Fatalf("auth_token_refreshes_total{outcome=%q} += %v, want 1", tt.want, got)
+			}
+		})
+	}
+}
+
+// fakeVerificationRepo는 이메일/전화번호별 코드를 메모리에 저장합니다.
+type fakeVerificationRepo struct {
+	VerificationRepository
+	codes map[string]string
+}
+
+func (r *fakeVerificationRepo) SaveCode(_ context.Context, email, code string) error {
+	r.codes[email] = code
+	return nil
+}
+func (r *fakeVerificationRepo) GetCode(_ context.Context, email string) (string, error) {
+	if code, ok := r.codes[email]; ok {
+		return code, nil
+	}
+	return "", domain.ErrNotFound
+}
+func (r *fakeVerificationRepo) StorePhoneVerificationCode(_ context.Context, phone, code string, _ time.Duration) error {
+	r.codes[phone] = code
+	return nil
+}
+func (r *fakeVerificationRepo) GetPhoneVerificationCode(ctx context.Context, phone string) (string, error) {
+	return r.GetCode(ctx, phone)
+}
+func (r *fakeVerificationRepo) DeletePhoneVerificationCode(_ context.Context, phone string) error {
+	delete(r.codes, phone)
+	return nil
+}
+
+type fakeSMSProvider struct{}
+
+func (fakeSMSProvider) SendVerificationSMS(context.Context, string, string) error { return nil }
+
+func TestVerificationCodeMetrics(t *testing.T) {
+	const (
+		email = "user@example.com"
+		phone = "+821012345678"
+	)
+	repo := &fakeVerificationRepo{codes: map[string]string{}}
+	uc := NewVerifyUseCase(repo, &fakeOutboxRepo{}, fakeSMSProvider{}, NewSettings(Features{PhoneVerification: true}, tokenRepo.SessionCap{}))
+	ctx := context.Background()
+
+	// 순서대로 실행: 발송 → 불일치 → 일치 → (휴대폰은 일치 시 삭제되므로) 만료
+	steps := []struct {
+		name    string
+		channel string
+		event   string
+		run     func() error
+	}{
+		{"email sent", channelEmail, codeSent, func() error { return uc.SendVerification(ctx, email) }},
+		{"email mismatch", channelEmail, codeMismatch, func() error { _, err := uc.VerifyCode(ctx, email, "zzzzzz"); return err }},
+		{"email verified", channelEmail, codeVerified, func() error { _, err := uc.VerifyCode(ctx, email, repo.codes[email]); return err }},
+		{"email expired", channelEmail, codeExpired, func() error { _, err := uc.VerifyCode(ctx, "other@example.com", "a1b2c3"); return err }},
+		{"sms sent", channelSMS, codeSent, func() error { return uc.SendPhoneVerification(ctx, phone) }},
+		{"sms mismatch", channelSMS, codeMismatch, func() error { _, err := uc.VerifyPhoneCode(ctx, phone, "abcdef"); return err }},
+		{"sms verified", channelSMS, codeVerified, func() error { _, err := uc.VerifyPhoneCode(ctx, phone, repo.codes[phone]); return err }},
+		{"sms expired", channelSMS, codeExpired, func() error { _, err := uc.VerifyPhoneCode(ctx, phone, "123456"); return err }},
+	}
+	for _, step := range steps {
+		var err error
+		got := counting(verificationCodesTotal.WithLabelValues(step.channel, step.event), func() { err = step.run() })
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got != 1 {
+			t.Fatalf("%s: auth_verification_codes_total{channel=%q,event=%q} += %v, want 1", step.name, step.channel, step.event, got)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// internal/usecase/outbox.go
+// 이 파일은 이메일 아웃박스(비동기 발송 대기열)의 조회 및 재처리 비즈니스 로직을 정의합니다.
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+)
+
+// OutboxRepository 인터페이스는 이메일 아웃박스 저장소를 추상화합니다.
+type OutboxRepository interface {
+	EnsureGroup(ctx context.Context) error                                                                         // 큐 초기화
+	Enqueue(ctx context.Context, msg *domain.OutboxMessage) error                                                  // 발송 대기열에 적재
+	Fetch(ctx context.Context, consumer string, count int64, block time.Duration) ([]*domain.OutboxMessage, error) // 처리할 메시지 조회
+	Ack(ctx context.Context, msg *domain.OutboxMessage) error                                                      // 처리 완료
+	Retry(ctx context.Context, msg *domain.OutboxMessage, at time.Time) error                                      // at 시각에 재시도
+	DeadLetter(ctx context.Context, msg *domain.OutboxMessage) error                                               // 데드레터 큐로 이동
+	PromoteDue(ctx context.Context, now time.Time) (int, error)                                                    // 재시도 시각이 된 메시지를 대기열로 복귀
+	IsSent(ctx context.Context, key string) (bool, error)                                                          // 멱등성 키 발송 여부
+	MarkSent(ctx context.Context, key string) error                                                                // 멱등성 키 발송 완료 기록
+	Stats(ctx context.Context) (domain.OutboxStats, error)                                                         // 큐별 적재 건수
+	Redrive(ctx context.Context, limit int64) (redriven, expired int, err error)                                   // 데드레터 메시지 재처리 (만료된 메시지는 삭제)
+}
+
+// OutboxUseCase 인터페이스는 운영자가 사용하는 아웃박스 관리 기능을 제공합니다.
+type OutboxUseCase interface {
+	Stats(ctx context.Context) (domain.OutboxStats, error)                     // 큐 상태 조회
+	Redrive(ctx context.Context, limit int) (redriven, expired int, err error) // 데드레터 메시지를 최대 limit 건 재처리
+}
+
+// defaultRedriveLimit은 limit 미지정 시 한 번에 재처리할 최대 건수입니다.
+const defaultRedriveLimit = 100
+
+type outboxUseCase struct {
+	repo OutboxRepository
+}
+
+// NewOutboxUseCase 생성자 함수는 아웃박스 저장소를 주입받아 OutboxUseCase 인스턴스를 반환합니다.
+func NewOutboxUseCase(repo OutboxRepository) OutboxUseCase {
+	return &outboxUseCase{repo: repo}
+}
+
+func (uc *outboxUseCase) Stats(ctx context.Context) (domain.OutboxStats, error) {
+	return uc.repo.Stats(ctx)
+}
+
+func (uc *outboxUseCase) Redrive(ctx context.Context, limit int) (int, int, error) {
+	if limit <= 0 {
+		limit = defaultRedriveLimit
+	}
+	return uc.repo.Redrive(ctx, int64(limit))
+}
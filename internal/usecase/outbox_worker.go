@@ -0,0 +1,296 @@
+// internal/usecase/outbox_worker.go
+// 이 파일은 아웃박스에 적재된 이메일을 백그라운드에서 발송하는 워커를 정의합니다.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	outboxQueueDepth = metrics.Factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mail_outbox_messages",
+			Help: "Number of messages in the mail outbox by queue",
+		},
+		[]string{"queue"},
+	)
+
+	outboxDeliveriesTotal = metrics.Factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mail_outbox_deliveries_total",
+			Help: "Total number of mail outbox delivery attempts by result",
+		},
+		[]string{"result"},
+	)
+)
+
+// OutboxWorkerConfig는 아웃박스 워커의 동작 설정입니다.
+type OutboxWorkerConfig struct {
+	Workers      int           // 동시에 발송하는 워커 수
+	BatchSize    int64         // 한 번에 가져오는 메시지 수
+	MaxAttempts  int           // 이 횟수만큼 실패하면 데드레터 처리
+	BaseBackoff  time.Duration // 첫 재시도 대기 시간 (시도마다 2배씩 증가)
+	MaxBackoff   time.Duration // 재시도 대기 시간 상한
+	PollInterval time.Duration // 새 메시지 대기 시간 및 재시도 큐 확인 주기
+}
+
+// DefaultOutboxWorkerConfig는 기본 워커 설정을 반환합니다.
+func DefaultOutboxWorkerConfig() OutboxWorkerConfig {
+	return OutboxWorkerConfig{
+		Workers:      2,
+		BatchSize:    10,
+		MaxAttempts:  8,
+		BaseBackoff:  5 * time.Second,
+		MaxBackoff:   30 * time.Minute,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// OutboxWorker는 아웃박스에서 메시지를 꺼내 MailSender로 발송합니다.
+type OutboxWorker struct {
+	repo   OutboxRepository
+	mailer MailSender
+	cfg    OutboxWorkerConfig
+	log    *zap.Logger
+}
+
+// NewOutboxWorker 생성자 함수는 저장소, 메일러, 설정을 주입받아 워커를 생성합니다.
+func NewOutboxWorker(repo OutboxRepository, mailer MailSender, cfg OutboxWorkerConfig, log *zap.Logger) *OutboxWorker {
+	defaults := DefaultOutboxWorkerConfig()
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaults.Workers
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaults.MaxAttempts
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = defaults.BaseBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = defaults.MaxBackoff
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	return &OutboxWorker{repo: repo, mailer: mailer, cfg: cfg, log: log}
+}
+
+// Run은 ctx가 취소될 때까지 발송 워커, 재시도 스케줄러, 큐 상태 수집기를 실행합니다.
+// 모든 고루틴이 종료된 후 반환합니다.
+func (w *OutboxWorker) Run(ctx context.Context) error {
+	if err := w.repo.EnsureGroup(ctx); err != nil {
+		return fmt.Errorf("outbox: ensure consumer group: %w", err)
+	}
+
+	host, _ := os.Hostname()
+
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.Workers; i++ {
+		consumer := fmt.Sprintf("%s-%d-%d", host, os.Getpid(), i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.consume(ctx, consumer)
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.every(ctx, w.cfg.PollInterval, w.promote)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.every(ctx, 15*time.Second, w.collectStats)
+	}()
+
+	wg.Wait()
+	return nil
+}
+
+// consume은 메시지를 가져와 순서대로 발송합니다.
+func (w *OutboxWorker) consume(ctx context.Context, consumer string) {
+	for ctx.Err() == nil {
+		msgs, err := w.repo.Fetch(ctx, consumer, w.cfg.BatchSize, w.cfg.PollInterval)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			w.log.Error("outbox fetch failed", zap.Error(err))
+			sleep(ctx, w.cfg.PollInterval)
+			continue
+		}
+		for _, msg := range msgs {
+			w.deliver(ctx, msg)
+		}
+	}
+}
+
+// deliver는 메시지 한 건을 발송하고 결과에 따라 Ack, 재시도, 데드레터 처리합니다.
+func (w *OutboxWorker) deliver(ctx context.Context, msg *domain.OutboxMessage) {
+	// 발송은 요청과 별개로 진행되므로 적재한 요청의 스팬을 부모가 아닌 링크로 연결
+	var opts []trace.SpanStartOption
+	if len(msg.Trace) > 0 {
+		origin := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(msg.Trace))
+		if sc := trace.SpanContextFromContext(origin); sc.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+	}
+	opts = append(opts, trace.WithNewRoot(), trace.WithAttributes(
+		attribute.String("outbox.message_id", msg.ID),
+		attribute.Int("outbox.attempt", msg.Attempts+1),
+	))
+	ctx, span := startSpan(ctx, "OutboxWorker.deliver", opts...)
+	defer span.End()
+
+	// 메시지를 적재한 요청의 ID로 발송 로그를 연계
+	if msg.RequestID != "" {
+		ctx = logger.WithRequestID(ctx, msg.RequestID)
+	}
+	log := logger.Ctx(ctx, w.log).With(zap.String("message_id", msg.ID), zap.Int("attempt", msg.Attempts+1))
+
+	// 재시도를 기다리는 동안 만료된 메시지(인증 코드 등)는 발송하지 않음
+	if msg.Expired(time.Now()) {
+		outboxDeliveriesTotal.WithLabelValues("expired").Inc()
+		log.Info("outbox message expired before delivery, dropping")
+		if err := w.repo.Ack(ctx, msg); err != nil {
+			log.Error("outbox ack failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 이전 시도에서 발송은 되었지만 Ack 전에 중단된 경우 중복 발송하지 않음
+	if msg.IdempotencyKey != "" {
+		sent, err := w.repo.IsSent(ctx, msg.IdempotencyKey)
+		if err != nil {
+			log.Error("outbox idempotency check failed", zap.Error(err))
+			return // Ack 하지 않으면 나중에 다시 회수됨
+		}
+		if sent {
+			outboxDeliveriesTotal.WithLabelValues("duplicate").Inc()
+			if err := w.repo.Ack(ctx, msg); err != nil {
+				log.Error("outbox ack failed", zap.Error(err))
+			}
+			return
+		}
+	}
+
+	sendErr := w.mailer.Send(domain.WithMailCategory(ctx, msg.Category), msg.To, msg.Subject, msg.Body)
+	if sendErr != nil {
+		span.RecordError(errors.New(logger.Redact(sendErr.Error())))
+		span.SetStatus(codes.Error, "delivery failed")
+	}
+	if sendErr == nil {
+		outboxDeliveriesTotal.WithLabelValues("sent").Inc()
+		if msg.IdempotencyKey != "" {
+			if err := w.repo.MarkSent(ctx, msg.IdempotencyKey); err != nil {
+				log.Warn("outbox mark sent failed", zap.Error(err))
+			}
+		}
+		if err := w.repo.Ack(ctx, msg); err != nil {
+			log.Error("outbox ack failed", zap.Error(err))
+		}
+		return
+	}
+
+	// 발송 에러에는 수신자 주소가 포함될 수 있으므로 가려서 저장하고 기록
+	msg.Attempts++
+	msg.LastError = logger.Redact(sendErr.Error())
+
+	if errors.Is(sendErr, domain.ErrPermanentDelivery) || msg.Attempts >= w.cfg.MaxAttempts {
+		outboxDeliveriesTotal.WithLabelValues("dead_letter").Inc()
+		log.Error("outbox message dead-lettered", logger.RedactedError(sendErr))
+		if err := w.repo.DeadLetter(ctx, msg); err != nil {
+			log.Error("outbox dead-letter failed", zap.Error(err))
+		}
+		return
+	}
+
+	delay := w.backoff(msg.Attempts)
+	outboxDeliveriesTotal.WithLabelValues("retry").Inc()
+	log.Warn("outbox delivery failed, retrying", logger.RedactedError(sendErr), zap.Duration("backoff", delay))
+	if err := w.repo.Retry(ctx, msg, time.Now().Add(delay)); err != nil {
+		log.Error("outbox retry schedule failed", zap.Error(err))
+	}
+}
+
+// backoff는 attempt 번째 실패 후의 대기 시간을 계산합니다 (지수 백오프 + ±20% 지터).
+func (w *OutboxWorker) backoff(attempt int) time.Duration {
+	d := w.cfg.BaseBackoff
+	for i := 1; i < attempt && d < w.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > w.cfg.MaxBackoff {
+		d = w.cfg.MaxBackoff
+	}
+	jitter := time.Duration(rand.Int64N(int64(d)/5*2+1)) - d/5
+	return d + jitter
+}
+
+// promote는 재시도 시각이 된 메시지를 발송 대기열로 되돌립니다.
+func (w *OutboxWorker) promote(ctx context.Context) {
+	if _, err := w.repo.PromoteDue(ctx, time.Now()); err != nil && ctx.Err() == nil {
+		w.log.Error("outbox promote failed", zap.Error(err))
+	}
+}
+
+// collectStats는 큐별 적재 건수를 메트릭으로 기록합니다.
+func (w *OutboxWorker) collectStats(ctx context.Context) {
+	stats, err := w.repo.Stats(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			w.log.Warn("outbox stats failed", zap.Error(err))
+		}
+		return
+	}
+	outboxQueueDepth.WithLabelValues("ready").Set(float64(stats.Ready))
+	outboxQueueDepth.WithLabelValues("pending").Set(float64(stats.Pending))
+	outboxQueueDepth.WithLabelValues("retry").Set(float64(stats.Retrying))
+	outboxQueueDepth.WithLabelValues("dead").Set(float64(stats.DeadLettered))
+}
+
+// every는 ctx가 취소될 때까지 interval마다 fn을 실행합니다.
+func (w *OutboxWorker) every(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sleep은 d 만큼 대기하되 ctx가 취소되면 즉시 반환합니다.
+func sleep(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
+}
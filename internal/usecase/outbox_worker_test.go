@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	"go.uber.org/zap"
+)
+
+// fakeOutboxRepo는 워커가 호출한 처리 결과를 기록합니다. 사용하지 않는 메서드는 구현하지 않습니다.
+type fakeOutboxRepo struct {
+	OutboxRepository
+	enqueued, acked, dead, retried []*domain.OutboxMessage
+}
+
+func (r *fakeOutboxRepo) Enqueue(_ context.Context, msg *domain.OutboxMessage) error {
+	r.enqueued = append(r.enqueued, msg)
+	return nil
+}
+
+func (r *fakeOutboxRepo) IsSent(context.Context, string) (bool, error) { return false, nil }
+func (r *fakeOutboxRepo) MarkSent(context.Context, string) error       { return nil }
+func (r *fakeOutboxRepo) Ack(_ context.Context, msg *domain.OutboxMessage) error {
+	r.acked = append(r.acked, msg)
+	return nil
+}
+func (r *fakeOutboxRepo) DeadLetter(_ context.Context, msg *domain.OutboxMessage) error {
+	r.dead = append(r.dead, msg)
+	return nil
+}
+func (r *fakeOutboxRepo) Retry(_ context.Context, msg *domain.OutboxMessage, _ time.Time) error {
+	r.retried = append(r.retried, msg)
+	return nil
+}
+
+type fakeMailer struct {
+	err   error
+	sends int
+}
+
+func (m *fakeMailer) Send(context.Context, string, string, string) error {
+	m.sends++
+	return m.err
+}
+
+func TestOutboxWorkerRedactsDeliveryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		dead bool
+	}{
+		{"permanent", fmt.Errorf("%w: smtp: 550 <victim@example.com> mailbox unavailable", domain.ErrPermanentDelivery), true},
+		{"transient", errors.New("smtp: 451 victim@example.com greylisted"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOutboxRepo{}
+			w := NewOutboxWorker(repo, &fakeMailer{err: tt.err}, OutboxWorkerConfig{}, zap.NewNop())
+			msg := &domain.OutboxMessage{ID: "m1", To: "victim@example.com"}
+
+			w.deliver(context.Background(), msg)
+
+			stored := repo.retried
+			if tt.dead {
+				stored = repo.dead
+			}
+			if len(stored) != 1 {
+				t.Fatalf("dead = %d, retried = %d", len(repo.dead), len(repo.retried))
+			}
+			if strings.Contains(stored[0].LastError, "victim@example.com") {
+				t.Fatalf("last error stores the recipient address: %q", stored[0].LastError)
+			}
+			if stored[0].LastError == "" {
+				t.Fatal("last error not recorded")
+			}
+		})
+	}
+}
+
+func TestOutboxWorkerDropsExpiredMessages(t *testing.T) {
+	repo := &fakeOutboxRepo{}
+	mailer := &fakeMailer{}
+	w := NewOutboxWorker(repo, mailer, OutboxWorkerConfig{}, zap.NewNop())
+
+	w.deliver(context.Background(), &domain.OutboxMessage{ID: "m1", To: "a@example.com", ExpiresAt: time.Now().Add(-time.Second)})
+	if mailer.sends != 0 {
+		t.Fatal("expired message was sent")
+	}
+	if len(repo.acked) != 1 {
+		t.Fatal("expired message was not removed from the queue")
+	}
+
+	w.deliver(context.Background(), &domain.OutboxMessage{ID: "m2", To: "a@example.com", ExpiresAt: time.Now().Add(time.Minute)})
+	if mailer.sends != 1 {
+		t.Fatal("message before expiry was not sent")
+	}
+}
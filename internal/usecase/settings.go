@@ -0,0 +1,54 @@
+// internal/usecase/settings.go
+// 이 파일은 재시작 없이 바꿀 수 있는 유스케이스 설정(기능 토글, 사용자당 동시 세션 수 제한)을 정의합니다.
+package usecase
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/aquaheyday/go-auth-service/internal/domain"
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+)
+
+// Features는 실행 중에 켜고 끌 수 있는 기능 목록입니다.
+type Features struct {
+	Signup            bool // 이메일 회원 가입
+	PhoneVerification bool // 휴대폰 인증 코드 발송
+}
+
+// Settings는 유스케이스가 요청마다 읽는 설정입니다. 설정 재로드 시 Set* 메서드로 교체합니다.
+type Settings struct {
+	features   atomic.Pointer[Features]
+	sessionCap atomic.Pointer[tokenRepo.SessionCap]
+}
+
+// NewSettings 생성자 함수는 기능 토글과 동시 세션 수 제한으로 Settings를 생성합니다.
+func NewSettings(features Features, sessionCap tokenRepo.SessionCap) *Settings {
+	s := &Settings{}
+	s.SetFeatures(features)
+	s.SetSessionCap(sessionCap)
+	return s
+}
+
+// SetFeatures는 이후 요청에 적용할 기능 토글을 교체합니다.
+func (s *Settings) SetFeatures(f Features) {
+	s.features.Store(&f)
+}
+
+// SetSessionCap은 이후 로그인에 적용할 동시 세션 수 제한을 교체합니다. 이미 로그인한 세션에는 다음 로그인 때 적용됩니다.
+func (s *Settings) SetSessionCap(c tokenRepo.SessionCap) {
+	s.sessionCap.Store(&c)
+}
+
+// SessionCap은 현재 동시 세션 수 제한입니다.
+func (s *Settings) SessionCap() tokenRepo.SessionCap {
+	return *s.sessionCap.Load()
+}
+
+// requireFeature는 기능이 꺼져 있으면 domain.ErrFeatureDisabled를 반환합니다.
+func (s *Settings) requireFeature(enabled func(*Features) bool, name string) error {
+	if !enabled(s.features.Load()) {
+		return fmt.Errorf("%w: %s", domain.ErrFeatureDisabled, name)
+	}
+	return nil
+}
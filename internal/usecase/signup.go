@@ -7,13 +7,13 @@ import (
 	"errors"
 
 	"github.com/aquaheyday/go-auth-service/internal/domain"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
 )
 
 // UserRepository 인터페이스는 사용자 생성 및 조회 기능을 추상화합니다.
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (string, error)      // 새 사용자 생성 및 ID 반환
-	GetByEmail(ctx context.Context, email string) (*domain.User, error) // 이메일로 사용자 조회
+	GetByEmail(ctx context.Context, email string) (*domain.User, error) // 이메일로 사용자 조회 (없으면 domain.ErrNotFound)
 }
 
 // SignupUseCase 인터페이스는 회원 가입 흐름(SignUp)을 정의합니다.
@@ -25,11 +25,12 @@ type SignupUseCase interface {
 type signupUseCase struct {
 	userRepo         UserRepository         // 사용자 저장소
 	verificationRepo VerificationRepository // 인증 코드 검증 저장소
+	settings         *Settings              // 기능 토글
 }
 
-// NewSignupUseCase 생성자 함수는 필요한 저장소를 주입받아 SignupUseCase 인스턴스를 반환합니다.
-func NewSignupUseCase(uRepo UserRepository, vRepo VerificationRepository) SignupUseCase {
-	return &signupUseCase{userRepo: uRepo, verificationRepo: vRepo}
+// NewSignupUseCase 생성자 함수는 필요한 저장소와 설정을 주입받아 SignupUseCase 인스턴스를 반환합니다.
+func NewSignupUseCase(uRepo UserRepository, vRepo VerificationRepository, settings *Settings) SignupUseCase {
+	return &signupUseCase{userRepo: uRepo, verificationRepo: vRepo, settings: settings}
 }
 
 // SignUp 메서드는 다음 순서로 회원 가입을 처리합니다:
@@ -37,18 +38,28 @@ func NewSignupUseCase(uRepo UserRepository, vRepo VerificationRepository) Signup
 // 2) 비밀번호 해시 생성
 // 3) 사용자 도메인 모델 생성 및 저장
 // 4) 생성된 사용자 ID 반환
-func (s *signupUseCase) SignUp(ctx context.Context, email, password, code string) (string, error) {
+func (s *signupUseCase) SignUp(ctx context.Context, email, password, code string) (_ string, err error) {
+	ctx, span := startSpan(ctx, "SignupUseCase.SignUp")
+	defer func() {
+		signupsTotal.WithLabelValues(signupOutcome(err)).Inc()
+		tracing.End(span, err)
+	}()
+
+	if err := s.settings.requireFeature(func(f *Features) bool { return f.Signup }, "signup"); err != nil {
+		return "", err
+	}
+
 	// 인증 코드 검증
 	valid, err := s.verificationRepo.VerifyCode(ctx, email, code)
 	if err != nil {
 		return "", err
 	}
 	if !valid {
-		return "", errors.New("invalid verification code")
+		return "", domain.ErrInvalidCode
 	}
 
 	// 비밀번호를 bcrypt로 해시 처리
-	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashed, err := hashPassword(ctx, password)
 	if err != nil {
 		return "", err
 	}
@@ -59,6 +70,22 @@ func (s *signupUseCase) SignUp(ctx context.Context, email, password, code string
 		PasswordHash: string(hashed),
 	}
 
-	// 저장소에 사용자 생성 요청 및 ID 반환
+	// 저장소에 사용자 생성 요청 및 ID 반환 (이미 가입된 이메일이면 domain.ErrAlreadyExists)
 	return s.userRepo.Create(ctx, user)
 }
+
+// signupOutcome은 SignUp 결과를 auth_signups_total 라벨로 변환합니다.
+func signupOutcome(err error) string {
+	switch {
+	case err == nil:
+		return signupSuccess
+	case errors.Is(err, domain.ErrInvalidCode):
+		return signupInvalidCode
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return signupAlreadyExists
+	case errors.Is(err, domain.ErrFeatureDisabled):
+		return signupDisabled
+	default:
+		return signupError
+	}
+}
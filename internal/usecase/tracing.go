@@ -0,0 +1,42 @@
+// internal/usecase/tracing.go
+// 이 파일은 유스케이스 메서드의 트레이싱 스팬 헬퍼를 정의합니다.
+package usecase
+
+import (
+	"context"
+
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const tracerName = "internal/usecase"
+
+// startSpan은 유스케이스 메서드 하나의 스팬을 시작합니다.
+func startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracing.Start(ctx, tracerName, name, opts...)
+}
+
+// hashPassword는 bcrypt 해시를 생성합니다. 비용이 큰 연산이므로 별도 스팬으로 기록합니다.
+func hashPassword(ctx context.Context, password string) (_ []byte, err error) {
+	_, span := startSpan(ctx, "bcrypt.GenerateFromPassword", trace.WithAttributes(
+		attribute.Int("bcrypt.cost", bcrypt.DefaultCost),
+	))
+	defer func() { tracing.End(span, err) }()
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+// dummyPasswordHash는 존재하지 않는 사용자의 로그인 시도에서 비교할 bcrypt 해시입니다.
+// 실제 해시와 같은 비용(bcrypt.DefaultCost)이어야 응답 시간으로 가입 여부를 추측할 수 없습니다.
+const dummyPasswordHash = "$2a$10$CW8Ggu2zQ21PxxoAw2MuuuJ1WSOm/pML.Hg9IvJ8KGJ02JxoO9gZa"
+
+// comparePassword는 bcrypt 해시와 비밀번호를 비교합니다.
+// 불일치는 정상 흐름이므로 스팬 에러로 기록하지 않습니다.
+func comparePassword(ctx context.Context, hash, password string) error {
+	_, span := startSpan(ctx, "bcrypt.CompareHashAndPassword")
+	defer span.End()
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	span.SetAttributes(attribute.Bool("bcrypt.match", err == nil))
+	return err
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDummyPasswordHashMatchesRealCost(t *testing.T) {
+	// 형식이 잘못된 해시는 비교 없이 즉시 실패하므로 타이밍 완화 효과가 없음
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	if err != nil {
+		t.Fatalf("dummy hash is not a valid bcrypt hash: %v", err)
+	}
+	if cost != bcrypt.DefaultCost {
+		t.Fatalf("dummy hash cost = %d, want bcrypt.DefaultCost %d", cost, bcrypt.DefaultCost)
+	}
+
+	err = comparePassword(context.Background(), dummyPasswordHash, "password123")
+	if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		t.Fatalf("comparePassword = %v, want a full mismatch comparison", err)
+	}
+}
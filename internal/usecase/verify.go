@@ -5,10 +5,19 @@ package usecase
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/aquaheyday/go-auth-service/internal/domain"
 	"github.com/aquaheyday/go-auth-service/internal/infra/sms"
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/aquaheyday/go-auth-service/pkg/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"math/big"
 	"strconv"
 	"strings"
 	"time"
@@ -27,29 +36,39 @@ type VerificationRepository interface {
 
 // MailSender 인터페이스는 이메일 전송 기능을 추상화합니다.
 type MailSender interface {
-	Send(to, subject, body string) error // 이메일 전송 구현체
+	Send(ctx context.Context, to, subject, body string) error // 이메일 전송 구현체 (ctx 취소 시 발송 중단)
 }
 
 // VerifyUseCase 인터페이스는 인증 코드 발송 및 검증 비즈니스 로직을 제공합니다.
 type VerifyUseCase interface {
-	SendVerification(ctx context.Context, email string) error         // 코드 생성 및 이메일 전송
-	VerifyCode(ctx context.Context, email, code string) (bool, error) // 코드 검증
+	SendVerification(ctx context.Context, email string) error                    // 코드 생성 및 이메일 전송
+	VerifyCode(ctx context.Context, email, code string) (bool, error)            // 코드 검증
+	SendPhoneVerification(ctx context.Context, phoneNumber string) error         // 코드 생성 및 SMS 전송
+	VerifyPhoneCode(ctx context.Context, phoneNumber, code string) (bool, error) // 휴대폰 코드 검증
 }
 
 // verifyUseCase 구조체는 실제 레포지토리와 메일러를 사용하여 VerifyUseCase를 구현합니다.
 type verifyUseCase struct {
 	repo        VerificationRepository // 코드 저장소
-	mailer      MailSender             // 이메일 발송기
+	outbox      OutboxRepository       // 이메일 발송 대기열 (실제 발송은 OutboxWorker가 수행)
 	smsProvider sms.SMSProvider
+	settings    *Settings // 기능 토글
 }
 
-// NewVerifyUseCase 생성자 함수는 repo와 mailer를 주입받아 UseCase 인스턴스를 반환합니다.
-func NewVerifyUseCase(repo VerificationRepository, mailer MailSender) VerifyUseCase {
-	return &verifyUseCase{repo: repo, mailer: mailer}
+// NewVerifyUseCase 생성자 함수는 repo, outbox, smsProvider, settings를 주입받아 UseCase 인스턴스를 반환합니다.
+// smsProvider가 nil이면 휴대폰 인증 코드 발송은 ErrSMSNotConfigured를 반환합니다.
+func NewVerifyUseCase(repo VerificationRepository, outbox OutboxRepository, smsProvider sms.SMSProvider, settings *Settings) VerifyUseCase {
+	return &verifyUseCase{repo: repo, outbox: outbox, smsProvider: smsProvider, settings: settings}
 }
 
-// SendVerification은 랜덤 3바이트(6 hex 문자열) 코드를 생성하여 저장하고 이메일로 전송합니다.
-func (v *verifyUseCase) SendVerification(ctx context.Context, email string) error {
+// ErrSMSNotConfigured는 SMS 프로바이더 없이 휴대폰 인증을 요청한 경우 반환됩니다.
+var ErrSMSNotConfigured = errors.New("sms provider not configured")
+
+// SendVerification은 랜덤 3바이트(6 hex 문자열) 코드를 생성하여 저장하고 이메일 발송 대기열에 적재합니다.
+func (v *verifyUseCase) SendVerification(ctx context.Context, email string) (err error) {
+	ctx, span := startSpan(ctx, "VerifyUseCase.SendVerification")
+	defer func() { tracing.End(span, err) }()
+
 	// 랜덤 바이트 생성
 	b := make([]byte, 3)
 	if _, err := rand.Read(b); err != nil {
@@ -63,77 +82,168 @@ func (v *verifyUseCase) SendVerification(ctx context.Context, email string) erro
 		return err
 	}
 
-	// 이메일 본문 생성 및 전송
-	body := fmt.Sprintf("Your verification code is: %s", code)
-	if err := v.mailer.Send(email, "Email Verification", body); err != nil {
-		// 전송 실패 시, 필요하다면 저장된 코드 삭제 고려
+	// 이메일 본문 생성 및 발송 대기열 적재
+	// 같은 이메일/코드 조합은 한 번만 발송되도록 멱등성 키를 지정하고, 코드가 만료되면 발송하지 않도록 만료 시각을 지정
+	now := time.Now()
+	msg := &domain.OutboxMessage{
+		ID:             uuid.New().String(),
+		IdempotencyKey: verificationIdempotencyKey(email, code),
+		Category:       domain.MailCategoryTransactional,
+		RequestID:      logger.RequestIDFromContext(ctx),
+		To:             email,
+		Subject:        "Email Verification",
+		Body:           fmt.Sprintf("Your verification code is: %s", code),
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(domain.EmailCodeTTL),
+		Trace:          make(map[string]string),
+	}
+	// 발송 워커의 스팬을 이 요청의 트레이스와 연결할 수 있도록 트레이스 컨텍스트 저장
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(msg.Trace))
+	if err := v.outbox.Enqueue(ctx, msg); err != nil {
+		// 적재 실패 시 발송되지 않을 코드가 남지 않도록 삭제
+		_ = v.repo.DeleteCode(ctx, email)
 		return err
 	}
 
+	verificationCodesTotal.WithLabelValues(channelEmail, codeSent).Inc()
 	return nil // 성공
 }
 
-// VerifyCode는 레포지토리를 통해 코드 일치 여부를 반환합니다.
-func (v *verifyUseCase) VerifyCode(ctx context.Context, email, code string) (bool, error) {
-	// 저장된 코드와 비교
-	ok, err := v.repo.VerifyCode(ctx, email, code)
+// verificationIdempotencyKey는 인증 메일의 멱등성 키를 만듭니다.
+// 키는 Redis에 남으므로 이메일 주소와 코드가 그대로 보이지 않도록 해시를 사용합니다.
+func verificationIdempotencyKey(email, code string) string {
+	sum := sha256.Sum256([]byte(email + "|" + code))
+	return "verify:" + hex.EncodeToString(sum[:16])
+}
+
+// VerifyCode는 저장된 코드와 일치하는지 반환합니다 (코드는 삭제하지 않으며, 가입 시 다시 확인).
+func (v *verifyUseCase) VerifyCode(ctx context.Context, email, code string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "VerifyUseCase.VerifyCode")
+	defer func() { tracing.End(span, err) }()
+
+	// 저장된 코드 조회 (없거나 만료된 경우 불일치로 처리)
+	storedCode, err := v.repo.GetCode(ctx, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		verificationCodesTotal.WithLabelValues(channelEmail, codeExpired).Inc()
+		return false, nil
+	}
 	if err != nil {
 		// 조회 에러 발생 시 전달
 		return false, err
 	}
-	// 일치 여부 반환
+
+	// 저장된 코드와 비교 후 일치 여부 반환
+	ok := subtle.ConstantTimeCompare([]byte(storedCode), []byte(code)) == 1
+	verificationCodesTotal.WithLabelValues(channelEmail, codeEvent(ok)).Inc()
 	return ok, nil
 }
 
-// 휴대폰 인증 코드 발송 기능
-func (uc *VerifyUseCase) SendPhoneVerification(ctx context.Context, phoneNumber string) error {
+// SendPhoneVerification은 6자리 숫자 코드를 생성하여 저장하고 SMS로 전송합니다.
+func (v *verifyUseCase) SendPhoneVerification(ctx context.Context, phoneNumber string) (err error) {
+	ctx, span := startSpan(ctx, "VerifyUseCase.SendPhoneVerification")
+	defer func() { tracing.End(span, err) }()
+
 	// 전화번호 포맷 확인 (+82로 시작하는지 등)
 	if !isValidPhoneNumber(phoneNumber) {
-		return errors.New("invalid phone number format")
+		return fmt.Errorf("%w: invalid phone number format", domain.ErrInvalidInput)
+	}
+	if v.smsProvider == nil {
+		return ErrSMSNotConfigured
+	}
+	if err := v.settings.requireFeature(func(f *Features) bool { return f.PhoneVerification }, "phone_verification"); err != nil {
+		return err
 	}
 
 	// 인증 코드 생성 (6자리 숫자)
-	code := generateNumericVerificationCode()
+	code, err := generateNumericVerificationCode()
+	if err != nil {
+		return err
+	}
 
 	// 인증 코드 저장 (10분 유효)
-	if err := uc.verificationRepo.StorePhoneVerificationCode(ctx, phoneNumber, code, time.Minute*10); err != nil {
+	if err := v.repo.StorePhoneVerificationCode(ctx, phoneNumber, code, time.Minute*10); err != nil {
 		return fmt.Errorf("failed to store verification code: %w", err)
 	}
 
 	// SMS 발송
-	if err := uc.smsProvider.SendVerificationSMS(ctx, phoneNumber, code); err != nil {
+	if err := v.sendSMS(ctx, phoneNumber, code); err != nil {
 		return fmt.Errorf("failed to send SMS: %w", err)
 	}
 
+	verificationCodesTotal.WithLabelValues(channelSMS, codeSent).Inc()
 	return nil
 }
 
-// 휴대폰 인증 코드 검증 기능
-func (uc *VerifyUseCase) VerifyPhoneCode(ctx context.Context, phoneNumber, code string) (bool, error) {
-	// 저장된 코드 조회
-	storedCode, err := uc.verificationRepo.GetPhoneVerificationCode(ctx, phoneNumber)
+// VerifyPhoneCode는 저장된 휴대폰 인증 코드와 일치하는지 확인하고, 일치하면 코드를 삭제합니다.
+func (v *verifyUseCase) VerifyPhoneCode(ctx context.Context, phoneNumber, code string) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "VerifyUseCase.VerifyPhoneCode")
+	defer func() { tracing.End(span, err) }()
+
+	// 저장된 코드 조회 (없거나 만료된 경우 불일치로 처리)
+	storedCode, err := v.repo.GetPhoneVerificationCode(ctx, phoneNumber)
+	if errors.Is(err, domain.ErrNotFound) {
+		verificationCodesTotal.WithLabelValues(channelSMS, codeExpired).Inc()
+		return false, nil
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to get verification code: %w", err)
 	}
 
 	// 코드 비교
-	if storedCode != code {
+	ok := storedCode == code
+	verificationCodesTotal.WithLabelValues(channelSMS, codeEvent(ok)).Inc()
+	if !ok {
 		return false, nil
 	}
 
 	// 검증 성공 시 코드 삭제
-	if err := uc.verificationRepo.DeletePhoneVerificationCode(ctx, phoneNumber); err != nil {
+	if err := v.repo.DeletePhoneVerificationCode(ctx, phoneNumber); err != nil {
 		return true, fmt.Errorf("failed to delete verification code: %w", err)
 	}
 
 	return true, nil
 }
 
+// sendSMS는 SMS 프로바이더 호출을 클라이언트 스팬으로 감싸고 지연 시간과 실패를 기록합니다 (전화번호는 기록하지 않음).
+func (v *verifyUseCase) sendSMS(ctx context.Context, phoneNumber, code string) (err error) {
+	ctx, span := tracing.StartClient(ctx, tracerName, "sms.SendVerificationSMS")
+	provider := smsProviderName(v.smsProvider)
+	start := time.Now()
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "failure"
+			smsProviderFailures.WithLabelValues(provider).Inc()
+		}
+		smsProviderDuration.Observe(ctx, time.Since(start).Seconds(), provider, result)
+		tracing.End(span, err)
+	}()
+	return v.smsProvider.SendVerificationSMS(ctx, phoneNumber, code)
+}
+
+// smsProviderName은 메트릭 라벨에 사용할 프로바이더 이름을 반환합니다.
+func smsProviderName(p sms.SMSProvider) string {
+	if named, ok := p.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return "unknown"
+}
+
+// codeEvent는 코드 일치 여부를 인증 코드 이벤트로 변환합니다.
+func codeEvent(ok bool) string {
+	if ok {
+		return codeVerified
+	}
+	return codeMismatch
+}
+
 // 숫자 인증 코드 생성 (6자리)
-func generateNumericVerificationCode() string {
-	rand.Seed(time.Now().UnixNano())
-	code := rand.Intn(900000) + 100000 // 100000-999999 사이의 숫자
-	return strconv.Itoa(code)
+func generateNumericVerificationCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(900000))
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(n.Int64()+100000, 10), nil // 100000-999999 사이의 숫자
 }
 
 // 전화번호 유효성 검사
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tokenRepo "github.com/aquaheyday/go-auth-service/internal/repository/token"
+)
+
+func TestVerificationIdempotencyKeyHidesEmailAndCode(t *testing.T) {
+	repo := &fakeVerificationRepo{codes: map[string]string{}}
+	outbox := &fakeOutboxRepo{}
+	uc := NewVerifyUseCase(repo, outbox, nil, NewSettings(Features{}, tokenRepo.SessionCap{}))
+
+	const email = "user@example.com"
+	if err := uc.SendVerification(context.Background(), email); err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox.enqueued) != 1 {
+		t.Fatalf("enqueued %d messages, want 1", len(outbox.enqueued))
+	}
+	key, code := outbox.enqueued[0].IdempotencyKey, repo.codes[email]
+	if strings.Contains(key, email) || strings.Contains(key, code) {
+		t.Fatalf("idempotency key %q exposes the email or code", key)
+	}
+	// 같은 이메일/코드 조합은 같은 키, 다르면 다른 키
+	if key != verificationIdempotencyKey(email, code) {
+		t.Fatalf("key %q is not derived from the email and code", key)
+	}
+	if key == verificationIdempotencyKey(email, "000000") || key == verificationIdempotencyKey("other@example.com", code) {
+		t.Fatal("different email/code pairs share an idempotency key")
+	}
+}
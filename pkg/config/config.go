@@ -1,51 +1,206 @@
+// pkg/config/config.go
+// 이 파일은 서버 전체 설정 구조를 정의합니다.
+// 값은 기본값 < YAML 설정 파일 < 환경 변수 < 명령행 플래그 순으로 덮어씁니다 (load.go).
 package config
 
-import (
-	"github.com/joho/godotenv"
-	"github.com/spf13/viper"
-	"log"
-)
+import "time"
 
+// Config는 서버 설정입니다. 각 필드의 키는 YAML 경로이며,
+// 환경 변수 이름은 키를 대문자로 바꾸고 "."를 "_"로 바꾼 것입니다 (예: smtp.tls_mode → SMTP_TLS_MODE).
+// secret 태그가 붙은 필드는 값 대신 file://, env://, local:// 등의 참조를 적을 수 있습니다 (secrets.go).
 type Config struct {
-	GRPCPort          string
-	DatabaseURL       string
-	RedisAddr         string
-	SMTPHost          string
-	SMTPPort          int
-	SMTPUser          string
-	SMTPPass          string
-	LogLevel          string
-	SendGridAPIKey    string
-	SendGridFromEmail string
-	SendGridFromName  string
-	SendGridSandbox   bool
-}
-
-func LoadConfig() (*Config, error) {
-	// 1) .env 파일 로드 (실패해도 무시)
-	if err := godotenv.Load(); err != nil {
-		log.Printf("No .env file found, relying on ENV vars: %v", err)
-	}
-
-	// 2) viper 설정
-	viper.AutomaticEnv()        // ENV 변수 우선
-	viper.SetConfigFile(".env") // .env 파일도 읽기
-	_ = viper.ReadInConfig()    // 파일 없으면 에러 무시
-
-	cfg := &Config{
-		GRPCPort:          viper.GetString("GRPC_PORT"),
-		DatabaseURL:       viper.GetString("DATABASE_URL"),
-		RedisAddr:         viper.GetString("REDIS_ADDR"),
-		SMTPHost:          viper.GetString("SMTP_HOST"),
-		SMTPPort:          viper.GetInt("SMTP_PORT"),
-		SMTPUser:          viper.GetString("SMTP_USER"),
-		SMTPPass:          viper.GetString("SMTP_PASS"),
-		LogLevel:          viper.GetString("LOG_LEVEL"),
-		SendGridAPIKey:    viper.GetString("SENDGRID_API_KEY"),
-		SendGridFromEmail: viper.GetString("SENDGRID_FROM_EMAIL"),
-		SendGridFromName:  viper.GetString("SENDGRID_FROM_NAME"),
-		SendGridSandbox:   viper.GetBool("SENDGRID_SANDBOX"),
-	}
-
-	return cfg, nil
+	Environment     string          `mapstructure:"environment" yaml:"environment"` // development, production
+	GRPC            GRPCConfig      `mapstructure:"grpc" yaml:"grpc"`
+	HTTP            HTTPConfig      `mapstructure:"http" yaml:"http"`
+	CORS            CORSConfig      `mapstructure:"cors" yaml:"cors"`
+	Database        DatabaseConfig  `mapstructure:"database" yaml:"database"`
+	Redis           RedisConfig     `mapstructure:"redis" yaml:"redis"`
+	Log             LogConfig       `mapstructure:"log" yaml:"log"`
+	SMTP            SMTPConfig      `mapstructure:"smtp" yaml:"smtp"`
+	SendGrid        SendGridConfig  `mapstructure:"sendgrid" yaml:"sendgrid"`
+	Mail            MailConfig      `mapstructure:"mail" yaml:"mail"`
+	Outbox          OutboxConfig    `mapstructure:"outbox" yaml:"outbox"`
+	Twilio          TwilioConfig    `mapstructure:"twilio" yaml:"twilio"`
+	TLS             TLSConfig       `mapstructure:"tls" yaml:"tls"`
+	Admin           AdminConfig     `mapstructure:"admin" yaml:"admin"`
+	Tracing         TracingConfig   `mapstructure:"tracing" yaml:"tracing"`
+	RateLimit       RateLimitConfig `mapstructure:"rate_limit" yaml:"rate_limit"`
+	Proxy           ProxyConfig     `mapstructure:"proxy" yaml:"proxy"`
+	Metrics         MetricsConfig   `mapstructure:"metrics" yaml:"metrics"`
+	JWT             JWTConfig       `mapstructure:"jwt" yaml:"jwt"`
+	Sessions        SessionsConfig  `mapstructure:"sessions" yaml:"sessions"`
+	Secrets         SecretsConfig   `mapstructure:"secrets" yaml:"secrets"`
+	Features        FeaturesConfig  `mapstructure:"features" yaml:"features"`
+	Reload          ReloadConfig    `mapstructure:"reload" yaml:"reload"`
+	ShutdownTimeout time.Duration   `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"` // 종료 신호 수신 후 모든 구성 요소 종료에 허용하는 시간
+	DrainDelay      time.Duration   `mapstructure:"drain_delay" yaml:"drain_delay"`           // NOT_SERVING 전환 후 새 요청 수신을 멈추기 전까지 기다리는 시간 (shutdown_timeout에 포함)
+}
+
+// GRPCConfig는 gRPC 서버 설정입니다.
+type GRPCConfig struct {
+	Port string `mapstructure:"port" yaml:"port"` // 수신 주소 (예: ":50051")
+}
+
+// HTTPConfig는 REST/JSON 게이트웨이 설정입니다.
+type HTTPConfig struct {
+	Port string `mapstructure:"port" yaml:"port"` // 수신 주소 (빈 값이면 게이트웨이 비활성화, tls.cert_file이 있으면 TLS로 제공)
+}
+
+// CORSConfig는 게이트웨이 CORS 설정입니다.
+type CORSConfig struct {
+	AllowedOrigins   []string `mapstructure:"allowed_origins" yaml:"allowed_origins"`     // 허용 Origin 목록
+	AllowCredentials bool     `mapstructure:"allow_credentials" yaml:"allow_credentials"` // 자격 증명 허용 여부
+}
+
+// DatabaseConfig는 Postgres 연결 설정입니다.
+type DatabaseConfig struct {
+	URL string `mapstructure:"url" yaml:"url" secret:"url"` // 접속 URL (출력 시 비밀번호는 가림)
+}
+
+// RedisConfig는 Redis 연결 설정입니다.
+type RedisConfig struct {
+	Addr string `mapstructure:"addr" yaml:"addr"`
+}
+
+// LogConfig는 로깅 설정입니다.
+type LogConfig struct {
+	Level string `mapstructure:"level" yaml:"level"` // debug, info, warn, error
+}
+
+// SMTPConfig는 smtp 메일 프로바이더 설정입니다. Host가 비어 있으면 프로바이더를 만들지 않습니다.
+type SMTPConfig struct {
+	Host     string `mapstructure:"host" yaml:"host"`
+	Port     int    `mapstructure:"port" yaml:"port"`
+	User     string `mapstructure:"user" yaml:"user"`
+	Pass     string `mapstructure:"pass" yaml:"pass" secret:"true"`
+	From     string `mapstructure:"from" yaml:"from"`
+	TLSMode  string `mapstructure:"tls_mode" yaml:"tls_mode"`   // none, starttls, tls
+	CAFile   string `mapstructure:"ca_file" yaml:"ca_file"`     // 사설 CA PEM 번들 (비어 있으면 시스템 CA)
+	PoolSize int    `mapstructure:"pool_size" yaml:"pool_size"` // 재사용할 연결 수
+}
+
+// SendGridConfig는 sendgrid 메일 프로바이더 설정입니다. APIKey가 비어 있으면 프로바이더를 만들지 않습니다.
+type SendGridConfig struct {
+	APIKey    string `mapstructure:"api_key" yaml:"api_key" secret:"true"`
+	FromEmail string `mapstructure:"from_email" yaml:"from_email"`
+	FromName  string `mapstructure:"from_name" yaml:"from_name"`
+	Sandbox   bool   `mapstructure:"sandbox" yaml:"sandbox"`
+}
+
+// MailConfig는 메일 프로바이더 선택 설정입니다.
+type MailConfig struct {
+	Primary   string              `mapstructure:"primary" yaml:"primary"`     // 기본 메일 프로바이더 (smtp, sendgrid, log, file)
+	Secondary string              `mapstructure:"secondary" yaml:"secondary"` // 기본 프로바이더 실패 시 사용할 프로바이더
+	Routes    map[string][]string `mapstructure:"routes" yaml:"routes"`       // 분류별 프로바이더 순서 (환경 변수는 "transactional=sendgrid,smtp")
+	FileDir   string              `mapstructure:"file_dir" yaml:"file_dir"`   // file 프로바이더가 .eml 파일을 저장할 디렉터리
+}
+
+// OutboxConfig는 메일 발송 대기열 워커 설정입니다.
+type OutboxConfig struct {
+	Workers     int `mapstructure:"workers" yaml:"workers"`
+	MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts"`
+}
+
+// TwilioConfig는 Twilio SMS 프로바이더 설정입니다. AccountSID가 비어 있으면 휴대폰 인증을 비활성화합니다.
+type TwilioConfig struct {
+	AccountSID string `mapstructure:"account_sid" yaml:"account_sid"`
+	AuthToken  string `mapstructure:"auth_token" yaml:"auth_token" secret:"true"`
+	FromNumber string `mapstructure:"from_number" yaml:"from_number"` // 발신 번호 (E.164)
+}
+
+// TLSConfig는 gRPC 서버 TLS 설정입니다.
+type TLSConfig struct {
+	CertFile       string        `mapstructure:"cert_file" yaml:"cert_file"`             // 서버 인증서 (비어 있으면 평문)
+	KeyFile        string        `mapstructure:"key_file" yaml:"key_file"`               // 서버 개인 키
+	ClientCAFile   string        `mapstructure:"client_ca_file" yaml:"client_ca_file"`   // 클라이언트 인증서 검증용 CA 번들
+	ClientAuth     string        `mapstructure:"client_auth" yaml:"client_auth"`         // 클라이언트 인증서 검증 방식 (none, optional, require)
+	ReloadInterval time.Duration `mapstructure:"reload_interval" yaml:"reload_interval"` // 인증서 파일 변경 확인 주기
+}
+
+// AdminConfig는 운영자용 AdminService 접근 설정입니다.
+type AdminConfig struct {
+	AllowedIdentities []string `mapstructure:"allowed_identities" yaml:"allowed_identities"` // 호출을 허용할 클라이언트 인증서 신원 (URI SAN, DNS SAN 또는 CN, 비어 있으면 모두 거부)
+}
+
+// TracingConfig는 OpenTelemetry 트레이싱 설정입니다.
+type TracingConfig struct {
+	Exporter     string  `mapstructure:"exporter" yaml:"exporter"`           // none, otlp, stdout, file
+	OTLPEndpoint string  `mapstructure:"otlp_endpoint" yaml:"otlp_endpoint"` // OTLP 수집기 주소 (비어 있으면 OTEL_EXPORTER_OTLP_ENDPOINT)
+	OTLPInsecure bool    `mapstructure:"otlp_insecure" yaml:"otlp_insecure"` // OTLP 수집기와 평문 통신
+	File         string  `mapstructure:"file" yaml:"file"`                   // file 익스포터 출력 경로
+	SampleRatio  float64 `mapstructure:"sample_ratio" yaml:"sample_ratio"`   // 루트 스팬 샘플링 비율 (0~1)
+}
+
+// RateLimitConfig는 속도 제한 설정입니다.
+type RateLimitConfig struct {
+	RPS          float64       `mapstructure:"rps" yaml:"rps"`                     // 정책 파일이 없을 때 클라이언트 IP별 초당 허용 요청 수
+	Burst        int           `mapstructure:"burst" yaml:"burst"`                 // 클라이언트 IP별 버스트 크기
+	TTL          time.Duration `mapstructure:"ttl" yaml:"ttl"`                     // 이 시간 동안 요청이 없는 클라이언트는 추적 중단
+	MaxClients   int           `mapstructure:"max_clients" yaml:"max_clients"`     // 추적할 최대 클라이언트 수 (초과 시 가장 오래된 클라이언트 제거)
+	Backend      string        `mapstructure:"backend" yaml:"backend"`             // 상태 저장소 (memory, redis)
+	RedisTimeout time.Duration `mapstructure:"redis_timeout" yaml:"redis_timeout"` // redis 저장소 검사 한 번에 허용하는 시간
+	PolicyFile   string        `mapstructure:"policy_file" yaml:"policy_file"`     // 메서드별 제한 정책 YAML 파일 (비어 있으면 모든 메서드에 IP별 기본 제한)
+}
+
+// ProxyConfig는 로드밸런서/프록시 뒤에서 클라이언트 주소를 판단하는 설정입니다.
+type ProxyConfig struct {
+	Trusted  []string `mapstructure:"trusted" yaml:"trusted"`   // X-Forwarded-For, PROXY protocol 헤더를 신뢰할 프록시 CIDR/IP 목록
+	Protocol string   `mapstructure:"protocol" yaml:"protocol"` // PROXY protocol 헤더 처리 방식 (off, optional, require)
+}
+
+// MetricsConfig는 메트릭/헬스 체크 HTTP 서버 설정입니다.
+type MetricsConfig struct {
+	Addr             string    `mapstructure:"addr" yaml:"addr"`
+	Path             string    `mapstructure:"path" yaml:"path"`                           // 메트릭 노출 경로
+	LatencyBuckets   []float64 `mapstructure:"latency_buckets" yaml:"latency_buckets"`     // 지연 시간 히스토그램 버킷 (초, 비어 있으면 Prometheus 기본값)
+	NativeHistograms bool      `mapstructure:"native_histograms" yaml:"native_histograms"` // 네이티브 히스토그램 기록 여부
+}
+
+// JWTConfig는 토큰 서명과 발급 정책 설정입니다.
+type JWTConfig struct {
+	AccessSecret    string                      `mapstructure:"access_secret" yaml:"access_secret" secret:"true"`   // 액세스 토큰 서명 키
+	RefreshSecret   string                      `mapstructure:"refresh_secret" yaml:"refresh_secret" secret:"true"` // 리프레시 토큰 서명 키
+	AccessTTL       time.Duration               `mapstructure:"access_ttl" yaml:"access_ttl"`                       // 액세스 토큰 유효 기간
+	RefreshTTL      time.Duration               `mapstructure:"refresh_ttl" yaml:"refresh_ttl"`                     // 리프레시 토큰 유효 기간
+	SessionLifetime time.Duration               `mapstructure:"session_lifetime" yaml:"session_lifetime"`           // 로그인부터 세션 최대 수명 (0이면 제한 없음)
+	IdleTimeout     time.Duration               `mapstructure:"idle_timeout" yaml:"idle_timeout"`                   // 토큰 갱신 없이 세션이 유지되는 기간 (0이면 제한 없음)
+	Issuer          string                      `mapstructure:"issuer" yaml:"issuer"`                               // iss 클레임 (비어 있으면 생략)
+	Audience        []string                    `mapstructure:"audience" yaml:"audience"`                           // aud 클레임 (비어 있으면 생략)
+	Clients         map[string]*JWTClientConfig `mapstructure:"clients" yaml:"clients"`                             // 클라이언트 ID별 재정의 (설정 파일로만 지정)
+}
+
+// JWTClientConfig는 클라이언트 하나의 토큰 정책 재정의입니다. 지정하지 않은 항목은 jwt 구역의 값을 따릅니다.
+// 클라이언트 ID는 소문자로 적어야 합니다 (설정 키는 대소문자를 구분하지 않음).
+// 로그인 요청은 client_id와 함께 Secret을 client_secret으로 보내야 해당 정책을 사용할 수 있습니다.
+type JWTClientConfig struct {
+	Secret          string        `mapstructure:"secret" yaml:"secret,omitempty" secret:"true"` // 클라이언트 인증 비밀 값 (필수)
+	AccessTTL       time.Duration `mapstructure:"access_ttl" yaml:"access_ttl,omitempty"`
+	RefreshTTL      time.Duration `mapstructure:"refresh_ttl" yaml:"refresh_ttl,omitempty"`
+	SessionLifetime time.Duration `mapstructure:"session_lifetime" yaml:"session_lifetime,omitempty"`
+	IdleTimeout     time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout,omitempty"`
+	Issuer          string        `mapstructure:"issuer" yaml:"issuer,omitempty"`
+	Audience        []string      `mapstructure:"audience" yaml:"audience,omitempty"`
+}
+
+// SessionsConfig는 사용자당 동시 세션(로그인) 수 제한 설정입니다.
+// 종료된 세션은 리프레시 토큰이 삭제되고, 이미 발급된 액세스 토큰은 만료 전이라도 거부됩니다.
+type SessionsConfig struct {
+	MaxPerUser int    `mapstructure:"max_per_user" yaml:"max_per_user"` // 사용자당 최대 활성 세션 수 (0이면 제한 없음)
+	OnLimit    string `mapstructure:"on_limit" yaml:"on_limit"`         // 최대치에서 새 로그인 처리 (reject, evict_oldest, evict_lru)
+}
+
+// SecretsConfig는 비밀 값 저장소 설정입니다.
+type SecretsConfig struct {
+	File string `mapstructure:"file" yaml:"file"` // local:// 참조를 조회할 YAML 파일 (비어 있으면 local:// 사용 불가)
+}
+
+// FeaturesConfig는 재시작 없이 켜고 끌 수 있는 기능 토글입니다.
+type FeaturesConfig struct {
+	Signup            bool `mapstructure:"signup" yaml:"signup"`                         // 이메일 회원 가입
+	PhoneVerification bool `mapstructure:"phone_verification" yaml:"phone_verification"` // 휴대폰 인증 코드 발송
+}
+
+// ReloadConfig는 설정 재로드 설정입니다. SIGHUP을 받으면 주기와 관계없이 다시 읽습니다.
+type ReloadConfig struct {
+	Interval time.Duration `mapstructure:"interval" yaml:"interval"` // 설정 파일 변경 확인 주기 (0이면 SIGHUP으로만 재로드)
 }
@@ -0,0 +1,286 @@
+// pkg/config/load.go
+// 이 파일은 기본값, YAML 설정 파일, 환경 변수, 명령행 플래그를 합쳐 Config를 만드는 과정을 정의합니다.
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/secrets"
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/joho/godotenv"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// defaultConfigFiles는 --config, CONFIG_FILE이 없을 때 순서대로 찾는 설정 파일입니다.
+// Docker 이미지는 작업 디렉터리에 config.yaml을 두고, 저장소에서 실행하면 configs/config.yaml을 사용합니다.
+var defaultConfigFiles = []string{"config.yaml", "configs/config.yaml"}
+
+// setting은 설정 키 하나의 기본값과 플래그 도움말입니다.
+// 기본값의 타입으로 플래그 타입을 정하며, 목록 값은 쉼표로 구분된 문자열로 받습니다.
+type setting struct {
+	key   string
+	value any
+	usage string
+}
+
+// settings는 모든 설정 키 목록입니다. 여기 없는 키는 환경 변수나 플래그로 설정할 수 없습니다.
+var settings = []setting{
+	{"environment", EnvDevelopment, "runtime environment (development, production)"},
+	{"grpc.port", ":50051", "gRPC listen address"},
+	{"http.port", ":8080", "REST/JSON gateway listen address (empty disables the gateway; served over TLS when tls.cert_file is set, loopback only without it in production)"},
+	{"cors.allowed_origins", "", "comma-separated origins allowed by the gateway CORS policy"},
+	{"cors.allow_credentials", false, "allow credentialed CORS requests"},
+	{"database.url", "", "Postgres connection URL"},
+	{"redis.addr", "", "Redis address (host:port)"},
+	{"log.level", "info", "log level (debug, info, warn, error)"},
+	{"smtp.host", "", "SMTP server host (empty disables the smtp provider)"},
+	{"smtp.port", 587, "SMTP server port"},
+	{"smtp.user", "", "SMTP username"},
+	{"smtp.pass", "", "SMTP password"},
+	{"smtp.from", "", "sender address for smtp and file providers"},
+	{"smtp.tls_mode", "starttls", "SMTP connection security (none, starttls, tls)"},
+	{"smtp.ca_file", "", "PEM bundle for a private SMTP server CA"},
+	{"smtp.pool_size", 0, "number of pooled SMTP connections"},
+	{"sendgrid.api_key", "", "SendGrid API key (empty disables the sendgrid provider)"},
+	{"sendgrid.from_email", "", "SendGrid sender address"},
+	{"sendgrid.from_name", "", "SendGrid sender name"},
+	{"sendgrid.sandbox", false, "enable SendGrid sandbox mode"},
+	{"mail.primary", "log", "primary mail provider (smtp, sendgrid, log, file; log and file are development only)"},
+	{"mail.secondary", "", "mail provider used when the primary fails"},
+	{"mail.routes", "", `per-category provider order, e.g. "transactional=sendgrid,smtp"`},
+	{"mail.file_dir", "", "directory for .eml files written by the file provider"},
+	{"outbox.workers", 2, "number of outbox delivery workers"},
+	{"outbox.max_attempts", 8, "delivery attempts before an outbox message is dead-lettered"},
+	{"twilio.account_sid", "", "Twilio account SID (empty disables phone verification)"},
+	{"twilio.auth_token", "", "Twilio auth token"},
+	{"twilio.from_number", "", "Twilio sender number in E.164 format"},
+	{"tls.cert_file", "", "gRPC server certificate (empty serves plaintext)"},
+	{"tls.key_file", "", "gRPC server private key"},
+	{"tls.client_ca_file", "", "CA bundle for verifying client certificates"},
+	{"tls.client_auth", "none", "client certificate policy (none, optional, require)"},
+	{"tls.reload_interval", 30 * time.Second, "interval for checking certificate files for changes"},
+	{"admin.allowed_identities", "", "comma-separated mTLS client identities allowed to call AdminService (empty denies all)"},
+	{"tracing.exporter", "none", "trace exporter (none, otlp, stdout, file)"},
+	{"tracing.otlp_endpoint", "", "OTLP collector address (default OTEL_EXPORTER_OTLP_ENDPOINT)"},
+	{"tracing.otlp_insecure", false, "use plaintext to the OTLP collector"},
+	{"tracing.file", "traces.jsonl", "output path for the file exporter"},
+	{"tracing.sample_ratio", 1.0, "root span sampling ratio (0-1)"},
+	{"rate_limit.rps", 100.0, "default per-IP requests per second"},
+	{"rate_limit.burst", 200, "default per-IP burst size"},
+	{"rate_limit.ttl", time.Hour, "idle time before a client bucket is dropped"},
+	{"rate_limit.max_clients", 100000, "maximum number of tracked clients"},
+	{"rate_limit.backend", "memory", "rate limiter store (memory, redis)"},
+	{"rate_limit.redis_timeout", 100 * time.Millisecond, "timeout for a single redis rate limit check"},
+	{"rate_limit.policy_file", "", "per-method rate limit policy YAML file"},
+	{"proxy.trusted", "", "comma-separated CIDRs/IPs of proxies trusted for client address headers"},
+	{"proxy.protocol", "off", "PROXY protocol handling (off, optional, require)"},
+	{"metrics.addr", ":9090", "metrics and health check listen address"},
+	{"metrics.path", "/metrics", "metrics endpoint path"},
+	{"metrics.latency_buckets", "", "comma-separated latency histogram buckets in seconds"},
+	{"metrics.native_histograms", true, "record native histograms"},
+	{"jwt.access_secret", defaultAccessSecret, "access token signing key"},
+	{"jwt.refresh_secret", defaultRefreshSecret, "refresh token signing key"},
+	{"jwt.access_ttl", 15 * time.Minute, "access token lifetime"},
+	{"jwt.refresh_ttl", 7 * 24 * time.Hour, "refresh token lifetime"},
+	{"jwt.session_lifetime", time.Duration(0), "maximum session lifetime from login, not extended by refreshes (0 disables)"},
+	{"jwt.idle_timeout", time.Duration(0), "maximum time a session stays valid without a token refresh (0 disables)"},
+	{"jwt.issuer", "", "iss claim of issued tokens (empty omits it)"},
+	{"jwt.audience", "", "comma-separated aud claim of issued tokens (empty omits it)"},
+	{"sessions.max_per_user", 0, "maximum active sessions per user (0 is unlimited)"},
+	{"sessions.on_limit", "evict_oldest", "what a login does at the session limit (reject, evict_oldest, evict_lru); evicted sessions have their tokens revoked"},
+	{"secrets.file", "", "YAML file backing local:// secret references"},
+	{"features.signup", true, "allow new email signups"},
+	{"features.phone_verification", true, "allow sending phone verification codes"},
+	{"reload.interval", 10 * time.Second, "interval for checking the config file for changes (0 reloads only on SIGHUP)"},
+	{"shutdown_timeout", 30 * time.Second, "time allowed for graceful shutdown"},
+	{"drain_delay", 5 * time.Second, "time between reporting NOT_SERVING and stopping the servers, so load balancers stop routing first"},
+}
+
+// envAliases는 키 이름에서 유도한 환경 변수 외에 계속 받는 이전 환경 변수 이름입니다.
+var envAliases = map[string][]string{
+	"database.url":  {"POSTGRES_URL"},
+	"proxy.trusted": {"TRUSTED_PROXIES"},
+}
+
+// secretTimeout은 외부 저장소에서 비밀 값을 모두 조회하는 데 허용하는 시간입니다.
+const secretTimeout = 10 * time.Second
+
+// Options는 설정 값 외에 명령행에서 받은 실행 옵션입니다.
+type Options struct {
+	File        string // 읽은 설정 파일 경로 (없으면 빈 문자열)
+	PrintConfig bool   // 설정을 출력하고 종료
+
+	// 재로드할 때 같은 방법으로 다시 읽기 위한 인자
+	args      []string
+	providers []secrets.Provider
+}
+
+// LoadConfig는 기본값, 설정 파일, 환경 변수(.env 포함), args의 플래그 순으로 설정을 합치고,
+// 비밀 필드의 참조를 providers(와 secrets.file)로 해석한 뒤 검증합니다.
+// 검증에 실패하면 모든 위반 사항을 모은 에러를 반환합니다. -h/--help이면 pflag.ErrHelp를 반환합니다.
+func LoadConfig(args []string, providers ...secrets.Provider) (*Config, Options, error) {
+	opts := Options{args: args, providers: providers}
+
+	// .env 파일 로드 (실패해도 무시)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("failed to load .env file: %v", err)
+	}
+
+	v := viper.New()
+	flags := pflag.NewFlagSet("auth-server", pflag.ContinueOnError)
+	flags.StringVar(&opts.File, "config", "", "config file path (default $CONFIG_FILE, ./config.yaml or ./configs/config.yaml)")
+	flags.BoolVar(&opts.PrintConfig, "print-config", false, "print the effective config with secrets redacted and exit")
+	for _, s := range settings {
+		v.SetDefault(s.key, s.value)
+		addFlag(flags, s)
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, opts, err
+	}
+	for _, s := range settings {
+		if err := v.BindPFlag(s.key, flags.Lookup(s.key)); err != nil {
+			return nil, opts, err
+		}
+	}
+
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	for key, names := range envAliases {
+		// 키에서 유도한 이름을 먼저 두어 둘 다 있으면 새 이름이 우선
+		if err := v.BindEnv(append([]string{key, envName(key)}, names...)...); err != nil {
+			return nil, opts, err
+		}
+	}
+
+	file, err := configFile(opts.File)
+	if err != nil {
+		return nil, opts, err
+	}
+	if file != "" {
+		v.SetConfigFile(file)
+		v.SetConfigType("yaml")
+		if err := v.ReadInConfig(); err != nil {
+			return nil, opts, fmt.Errorf("read config file %s: %w", file, err)
+		}
+		opts.File = file
+	}
+
+	cfg := &Config{}
+	// 설정 파일의 오타를 찾기 위해 구조체에 없는 키가 있으면 실패
+	if err := v.UnmarshalExact(cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		stringToRoutesHook,
+		stringToListHook,
+	))); err != nil {
+		return nil, opts, fmt.Errorf("decode config: %w", err)
+	}
+
+	if cfg.Secrets.File != "" {
+		store, err := secrets.NewFileStore(cfg.Secrets.File)
+		if err != nil {
+			return nil, opts, err
+		}
+		providers = append(providers, store)
+	} else {
+		providers = append([]secrets.Provider{secrets.Unavailable(secrets.SchemeLocal, "secrets.file is not set")}, providers...)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), secretTimeout)
+	defer cancel()
+	if err := resolveSecrets(ctx, cfg, secrets.NewResolver(providers...)); err != nil {
+		return nil, opts, fmt.Errorf("resolve secrets:\n%w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, opts, fmt.Errorf("invalid config:\n%w", err)
+	}
+	return cfg, opts, nil
+}
+
+// configFile은 읽을 설정 파일 경로를 정합니다. 명시한 파일은 반드시 있어야 하고,
+// 기본 위치에 파일이 없으면 빈 문자열을 반환합니다 (환경 변수와 플래그만 사용).
+func configFile(explicit string) (string, error) {
+	if explicit == "" {
+		explicit = os.Getenv("CONFIG_FILE")
+	}
+	if explicit != "" {
+		if _, err := os.Stat(explicit); err != nil {
+			return "", fmt.Errorf("config file: %w", err)
+		}
+		return explicit, nil
+	}
+	for _, path := range defaultConfigFiles {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// addFlag는 설정 키와 같은 이름의 플래그를 기본값 타입에 맞게 등록합니다 (예: --smtp.port 2525).
+func addFlag(flags *pflag.FlagSet, s setting) {
+	switch value := s.value.(type) {
+	case bool:
+		flags.Bool(s.key, value, s.usage)
+	case int:
+		flags.Int(s.key, value, s.usage)
+	case float64:
+		flags.Float64(s.key, value, s.usage)
+	case time.Duration:
+		flags.Duration(s.key, value, s.usage)
+	default:
+		flags.String(s.key, fmt.Sprint(value), s.usage)
+	}
+}
+
+// envName은 설정 키에 대응하는 환경 변수 이름입니다 (예: smtp.tls_mode → SMTP_TLS_MODE).
+func envName(key string) string {
+	return strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// stringToListHook은 환경 변수와 플래그로 받은 쉼표 구분 문자열을 목록으로 변환합니다.
+// 각 항목의 공백은 제거하고 빈 항목은 버립니다.
+func stringToListHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+		return data, nil
+	}
+	return splitList(data.(string)), nil
+}
+
+// stringToRoutesHook은 "transactional=sendgrid,smtp" 형식의 메일 경로 문자열을 변환합니다.
+func stringToRoutesHook(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(map[string][]string(nil)) {
+		return data, nil
+	}
+	routes := make(map[string][]string)
+	for _, entry := range strings.Split(data.(string), ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		category, list, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("mail routes: invalid entry %q (expected category=provider,...)", entry)
+		}
+		routes[strings.TrimSpace(category)] = splitList(list)
+	}
+	return routes, nil
+}
+
+// splitList는 쉼표로 구분된 문자열을 목록으로 변환합니다.
+func splitList(s string) []string {
+	list := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,93 @@
+// pkg/config/print.go
+// 이 파일은 --print-config로 최종 설정을 출력할 때 비밀 값을 가리는 방법을 정의합니다.
+package config
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redactedValue는 가린 비밀 값 대신 출력하는 문자열입니다.
+const redactedValue = "REDACTED"
+
+// Redacted는 비밀 필드를 가린 복사본을 반환합니다. URL 필드는 비밀번호만 가리고 (redactURL), 빈 값은 그대로 둡니다.
+func (c *Config) Redacted() *Config {
+	out := *c
+	// 맵 항목은 포인터이므로 원본을 바꾸지 않도록 복사본에서 가림
+	if c.JWT.Clients != nil {
+		out.JWT.Clients = make(map[string]*JWTClientConfig, len(c.JWT.Clients))
+		for id, client := range c.JWT.Clients {
+			if client != nil {
+				copied := *client
+				client = &copied
+			}
+			out.JWT.Clients[id] = client
+		}
+	}
+	for _, f := range secretFields(&out) {
+		switch {
+		case f.value.String() == "":
+		case f.kind == "url":
+			f.value.SetString(redactURL(f.value.String()))
+		default:
+			f.value.SetString(redactedValue)
+		}
+	}
+	return &out
+}
+
+// redactURL은 접속 문자열의 비밀번호를 가립니다.
+// URL 형식은 사용자 정보와 쿼리의 비밀번호 파라미터를, 키워드 형식(host=db password=...)은 비밀번호 키의 값을 가리며,
+// 해석할 수 없으면 전체를 가립니다.
+func redactURL(raw string) string {
+	if !strings.Contains(raw, "://") {
+		return redactKeywordDSN(raw)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redactedValue
+	}
+	if _, ok := u.User.Password(); ok {
+		u.User = url.UserPassword(u.User.Username(), redactedValue)
+	}
+	if u.RawQuery != "" {
+		// url.Values로 다시 인코딩하면 파라미터 순서가 바뀌므로 원문을 그대로 고침
+		params := strings.Split(u.RawQuery, "&")
+		for i, param := range params {
+			key, _, _ := strings.Cut(param, "=")
+			if name, err := url.QueryUnescape(key); err != nil || isPasswordKey(name) {
+				params[i] = key + "=" + redactedValue
+			}
+		}
+		u.RawQuery = strings.Join(params, "&")
+	}
+	return u.String()
+}
+
+// keywordPassword는 키워드 형식 접속 문자열의 비밀번호 항목입니다. 값은 작은따옴표로 감쌀 수 있습니다.
+var keywordPassword = regexp.MustCompile(`(?i)(^|\s)([a-z_]*(?:password|pass|pwd))\s*=\s*(?:'(?:\\.|[^'\\])*'?|\S*)`)
+
+// redactKeywordDSN은 키워드 형식 접속 문자열(libpq)의 비밀번호 값을 가립니다.
+func redactKeywordDSN(raw string) string {
+	return keywordPassword.ReplaceAllString(raw, "${1}${2}="+redactedValue)
+}
+
+// isPasswordKey는 접속 파라미터 이름이 비밀번호를 담는지 확인합니다 (password, sslpassword 등).
+func isPasswordKey(name string) bool {
+	name = strings.ToLower(name)
+	return strings.HasSuffix(name, "password") || name == "pass" || name == "pwd"
+}
+
+// WriteRedacted는 비밀 값을 가린 설정을 YAML로 w에 씁니다. 출력은 그대로 설정 파일로 사용할 수 있습니다.
+func (c *Config) WriteRedacted(w io.Writer) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(c.Redacted()); err != nil {
+		return err
+	}
+	return enc.Close()
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"userinfo password", "postgres://app:hunter2@db:5432/auth?sslmode=disable", "postgres://app:REDACTED@db:5432/auth?sslmode=disable"},
+		{"no password", "postgres://app@db/auth", "postgres://app@db/auth"},
+		{"query password", "postgres://app@db/auth?password=hunter2", "postgres://app@db/auth?password=REDACTED"},
+		{"query password keeps order", "postgres://app@db/auth?sslmode=require&Password=hunter2&connect_timeout=5", "postgres://app@db/auth?sslmode=require&Password=REDACTED&connect_timeout=5"},
+		{"query sslpassword", "postgres://app@db/auth?sslpassword=hunter2", "postgres://app@db/auth?sslpassword=REDACTED"},
+		{"userinfo and query", "postgres://app:hunter2@db/auth?password=hunter2", "postgres://app:REDACTED@db/auth?password=REDACTED"},
+		{"keyword dsn", "host=db user=app password=hunter2 dbname=auth", "host=db user=app password=REDACTED dbname=auth"},
+		{"keyword dsn spaces around equals", "host=db password = hunter2 dbname=auth", "host=db password=REDACTED dbname=auth"},
+		{"keyword dsn quoted", `host=db password='hunter 2\'s' dbname=auth`, "host=db password=REDACTED dbname=auth"},
+		{"keyword dsn first", "password=hunter2 host=db", "password=REDACTED host=db"},
+		{"keyword dsn sslpassword", "host=db sslpassword=hunter2", "host=db sslpassword=REDACTED"},
+		{"keyword dsn without password", "host=db user=app dbname=auth", "host=db user=app dbname=auth"},
+		{"unparsable url", "postgres://app:hunter2@db:port/auth", "REDACTED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := redactURL(tt.in)
+			if got != tt.want {
+				t.Fatalf("redactURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if strings.Contains(got, "hunter") {
+				t.Fatalf("password leaked: %q", got)
+			}
+		})
+	}
+}
+
+func TestRedactedMasksClientSecretsWithoutMutating(t *testing.T) {
+	c := &Config{}
+	c.JWT.Clients = map[string]*JWTClientConfig{
+		"mobile": {Secret: "mobile-secret", AccessTTL: time.Minute},
+		"web":    nil,
+	}
+
+	out := c.Redacted()
+	if got := out.JWT.Clients["mobile"].Secret; got != redactedValue {
+		t.Fatalf("redacted client secret = %q", got)
+	}
+	if got := out.JWT.Clients["mobile"].AccessTTL; got != time.Minute {
+		t.Fatalf("non-secret field changed: %v", got)
+	}
+	if got := c.JWT.Clients["mobile"].Secret; got != "mobile-secret" {
+		t.Fatalf("Redacted modified the original config: %q", got)
+	}
+}
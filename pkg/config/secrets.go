@@ -0,0 +1,122 @@
+// pkg/config/secrets.go
+// 이 파일은 secret 태그가 붙은 설정 값의 참조 해석과 production 환경의 기본 비밀 값 검사를 정의합니다.
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/aquaheyday/go-auth-service/pkg/secrets"
+)
+
+// 실행 환경
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production" // 기본 비밀 값을 쓰면 시작하지 않음
+)
+
+// 개발 편의를 위한 기본 JWT 서명 키입니다. production 환경에서는 사용할 수 없습니다.
+const (
+	defaultAccessSecret  = "access-secret-key"
+	defaultRefreshSecret = "refresh-secret-key"
+)
+
+// minSecretLength는 production 환경에서 JWT 서명 키에 요구하는 최소 바이트 수입니다 (HS256 키 길이).
+const minSecretLength = 32
+
+// insecureDefaults는 기본값 그대로 쓰면 안 되는 비밀 설정입니다.
+var insecureDefaults = map[string]string{
+	"jwt.access_secret":  defaultAccessSecret,
+	"jwt.refresh_secret": defaultRefreshSecret,
+}
+
+// secretField는 secret 태그가 붙은 설정 필드입니다.
+// secret:"true"는 값 전체가, secret:"url"은 URL의 비밀번호가 비밀입니다.
+type secretField struct {
+	key   string // YAML 경로 (예: smtp.pass)
+	kind  string
+	value reflect.Value
+}
+
+// secretFields는 c의 비밀 필드 목록을 반환합니다. 반환된 값으로 c의 필드를 바꿀 수 있습니다.
+func secretFields(c *Config) []secretField {
+	var fields []secretField
+	var walk func(v reflect.Value, prefix string)
+	walk = func(v reflect.Value, prefix string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			key := prefix + t.Field(i).Tag.Get("mapstructure")
+			switch f := v.Field(i); {
+			case f.Kind() == reflect.Struct:
+				walk(f, key+".")
+			case f.Kind() == reflect.Map && f.Type().Elem().Kind() == reflect.Pointer && f.Type().Elem().Elem().Kind() == reflect.Struct:
+				// 구조체 포인터를 값으로 갖는 맵 (예: jwt.clients)은 키 순서대로 각 항목을 검사
+				keys := f.MapKeys()
+				slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+				for _, k := range keys {
+					if elem := f.MapIndex(k); !elem.IsNil() {
+						walk(elem.Elem(), key+"."+k.String()+".")
+					}
+				}
+			case f.Kind() == reflect.String && t.Field(i).Tag.Get("secret") != "":
+				fields = append(fields, secretField{key: key, kind: t.Field(i).Tag.Get("secret"), value: f})
+			}
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "")
+	return fields
+}
+
+// resolveSecrets는 비밀 필드에 적힌 참조(file://, env://, local:// 등)를 resolver로 조회한 값으로 바꿉니다.
+// 참조가 아닌 값은 그대로 두며, 실패한 필드를 모두 모아 반환합니다.
+func resolveSecrets(ctx context.Context, c *Config, resolver *secrets.Resolver) error {
+	var errs []error
+	for _, f := range secretFields(c) {
+		if !resolver.IsReference(f.value.String()) {
+			continue
+		}
+		value, err := resolver.Resolve(ctx, f.value.String())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.key, err))
+			continue
+		}
+		f.value.SetString(value)
+	}
+	return errors.Join(errs...)
+}
+
+// DefaultSecrets는 기본 비밀 값을 그대로 쓰고 있는 설정 키 목록을 반환합니다.
+func (c *Config) DefaultSecrets() []string {
+	var keys []string
+	for _, f := range secretFields(c) {
+		if def, ok := insecureDefaults[f.key]; ok && f.value.String() == def {
+			keys = append(keys, f.key)
+		}
+	}
+	return keys
+}
+
+// validateSecrets는 비밀 값을 검사합니다. production 환경에서는 기본값과 짧은 서명 키를 거부합니다.
+func (c *Config) validateSecrets(v *validator) {
+	v.oneOf("environment", c.Environment, EnvDevelopment, EnvProduction)
+	v.check(c.JWT.AccessSecret != "", "jwt.access_secret", "is required")
+	v.check(c.JWT.RefreshSecret != "", "jwt.refresh_secret", "is required")
+	if c.Environment != EnvProduction {
+		return
+	}
+
+	for _, key := range c.DefaultSecrets() {
+		v.check(false, key, "must not use the built-in default secret in production")
+	}
+	v.check(len(c.JWT.AccessSecret) >= minSecretLength, "jwt.access_secret", "must be at least %d bytes in production", minSecretLength)
+	v.check(len(c.JWT.RefreshSecret) >= minSecretLength, "jwt.refresh_secret", "must be at least %d bytes in production", minSecretLength)
+	v.check(c.JWT.AccessSecret != c.JWT.RefreshSecret, "jwt", "access_secret and refresh_secret must differ in production")
+	if u := c.Database.URL; u != "" {
+		// 예시 설정 파일의 자격 증명을 그대로 배포하는 실수 방지
+		v.check(!strings.Contains(u, "user:pass@"), "database.url", "must not use the example credentials in production")
+	}
+}
@@ -0,0 +1,207 @@
+// pkg/config/validate.go
+// 이 파일은 서버 시작 전에 설정 값을 검사하는 규칙을 정의합니다.
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 메일 프로바이더 이름 (internal/infra/mailer의 Provider* 상수와 같음)
+var mailProviders = []string{"smtp", "sendgrid", "log", "file"}
+
+// devMailProviders는 메일을 실제로 보내지 않는 로컬 개발용 프로바이더입니다 (production에서 사용 불가).
+var devMailProviders = []string{"log", "file"}
+
+// clientIDPattern은 토큰 정책을 재정의하는 클라이언트 ID 형식입니다 (LoginReq.client_id 검증 규칙과 같음).
+var clientIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// validator는 위반 사항을 모읍니다.
+type validator struct {
+	errs []error
+}
+
+// check는 ok가 false이면 "key: 설명" 형식의 위반을 기록합니다.
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+// oneOf는 value가 allowed 중 하나인지 검사합니다.
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	v.check(slices.Contains(allowed, value), key, "must be one of %s (got %q)", strings.Join(allowed, ", "), value)
+}
+
+// Validate는 설정 전체를 검사하고, 위반 사항을 모두 모아 하나의 에러로 반환합니다.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	v.check(c.GRPC.Port != "", "grpc.port", "is required")
+	v.check(c.Database.URL != "", "database.url", "is required")
+	if c.Database.URL != "" {
+		_, err := url.Parse(c.Database.URL)
+		v.check(err == nil, "database.url", "must be a valid URL")
+	}
+	v.check(c.Redis.Addr != "", "redis.addr", "is required")
+	v.oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	v.check(c.ShutdownTimeout > 0, "shutdown_timeout", "must be positive")
+	v.check(c.DrainDelay >= 0 && c.DrainDelay < c.ShutdownTimeout, "drain_delay", "must be between 0 and shutdown_timeout")
+	// "*"에 자격 증명을 허용하면 모든 사이트가 사용자의 쿠키로 요청하고 응답을 읽을 수 있음
+	v.check(!c.CORS.AllowCredentials || !slices.Contains(c.CORS.AllowedOrigins, "*"),
+		"cors.allow_credentials", `cannot be used with allowed_origins "*"; list the trusted origins explicitly`)
+
+	c.validateSecrets(v)
+	c.validateMail(v)
+
+	v.check(c.Outbox.Workers > 0, "outbox.workers", "must be positive")
+	v.check(c.Outbox.MaxAttempts > 0, "outbox.max_attempts", "must be positive")
+
+	twilioSet := []bool{c.Twilio.AccountSID != "", c.Twilio.AuthToken != "", c.Twilio.FromNumber != ""}
+	v.check(!slices.Contains(twilioSet, true) || !slices.Contains(twilioSet, false),
+		"twilio", "account_sid, auth_token and from_number must be set together")
+
+	v.check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls", "cert_file and key_file must be set together")
+	v.oneOf("tls.client_auth", c.TLS.ClientAuth, "none", "optional", "require")
+	if c.TLS.ClientAuth != "none" {
+		v.check(c.TLS.CertFile != "", "tls.client_auth", "requires tls.cert_file")
+		v.check(c.TLS.ClientCAFile != "", "tls.client_auth", "requires tls.client_ca_file")
+	}
+	v.check(c.TLS.ReloadInterval >= 0, "tls.reload_interval", "must not be negative")
+	if c.HTTP.Port != "" && c.TLS.CertFile == "" && c.Environment == EnvProduction {
+		// 게이트웨이는 비밀번호와 토큰을 받으므로 평문은 같은 호스트의 프록시 뒤에서만 허용
+		v.check(isLoopback(c.HTTP.Port), "http.port", "must be a loopback address when tls.cert_file is not set (got %q)", c.HTTP.Port)
+	}
+	if len(c.Admin.AllowedIdentities) > 0 {
+		// AdminService는 검증된 클라이언트 인증서로만 호출할 수 있음
+		v.check(c.TLS.ClientAuth != "none", "admin.allowed_identities", "requires tls.client_auth optional or require")
+	}
+
+	v.oneOf("tracing.exporter", c.Tracing.Exporter, "none", "otlp", "stdout", "file")
+	if c.Tracing.Exporter == "file" {
+		v.check(c.Tracing.File != "", "tracing.file", "is required for the file exporter")
+	}
+	v.check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be between 0 and 1")
+
+	v.check(c.RateLimit.RPS >= 0, "rate_limit.rps", "must not be negative")
+	if c.RateLimit.RPS > 0 {
+		v.check(c.RateLimit.Burst > 0, "rate_limit.burst", "must be positive")
+	}
+	v.check(c.RateLimit.TTL > 0, "rate_limit.ttl", "must be positive")
+	v.check(c.RateLimit.MaxClients > 0, "rate_limit.max_clients", "must be positive")
+	v.oneOf("rate_limit.backend", c.RateLimit.Backend, "memory", "redis")
+	v.check(c.RateLimit.RedisTimeout > 0, "rate_limit.redis_timeout", "must be positive")
+
+	for _, entry := range c.Proxy.Trusted {
+		_, perr := netip.ParsePrefix(entry)
+		_, aerr := netip.ParseAddr(entry)
+		v.check(perr == nil || aerr == nil, "proxy.trusted", "invalid CIDR or IP %q", entry)
+	}
+	v.oneOf("proxy.protocol", c.Proxy.Protocol, "off", "optional", "require")
+	if c.Proxy.Protocol != "off" {
+		v.check(len(c.Proxy.Trusted) > 0, "proxy.protocol", "requires proxy.trusted")
+	}
+
+	c.validateTokenPolicies(v)
+	v.check(c.Sessions.MaxPerUser >= 0, "sessions.max_per_user", "must not be negative")
+	v.oneOf("sessions.on_limit", c.Sessions.OnLimit, "reject", "evict_oldest", "evict_lru")
+	v.check(c.Reload.Interval >= 0, "reload.interval", "must not be negative")
+
+	v.check(c.Metrics.Addr != "", "metrics.addr", "is required")
+	v.check(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path", "must start with /")
+	for i, b := range c.Metrics.LatencyBuckets {
+		if i > 0 && b <= c.Metrics.LatencyBuckets[i-1] {
+			v.check(false, "metrics.latency_buckets", "values must be in increasing order (%v after %v)", b, c.Metrics.LatencyBuckets[i-1])
+			break
+		}
+	}
+
+	return errors.Join(v.errs...)
+}
+
+// isLoopback은 addr("host:port")의 호스트가 루프백 주소인지 확인합니다 (빈 호스트는 모든 인터페이스).
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.IsLoopback()
+}
+
+// validateMail은 메일 프로바이더 선택이 실제로 만들 수 있는 프로바이더만 가리키는지 검사합니다.
+func (c *Config) validateMail(v *validator) {
+	configured := map[string]bool{
+		"log":      true,
+		"smtp":     c.SMTP.Host != "",
+		"sendgrid": c.SendGrid.APIKey != "",
+		"file":     c.Mail.FileDir != "",
+	}
+	provider := func(key, name string) {
+		v.oneOf(key, name, mailProviders...)
+		if slices.Contains(mailProviders, name) {
+			v.check(configured[name], key, "provider %q is not configured", name)
+		}
+		v.check(c.Environment != EnvProduction || !slices.Contains(devMailProviders, name),
+			key, "provider %q does not deliver mail and cannot be used in production", name)
+	}
+
+	provider("mail.primary", c.Mail.Primary)
+	if c.Mail.Secondary != "" {
+		provider("mail.secondary", c.Mail.Secondary)
+	}
+	for _, category := range slices.Sorted(maps.Keys(c.Mail.Routes)) {
+		names := c.Mail.Routes[category]
+		key := "mail.routes." + category
+		v.check(len(names) > 0, key, "must list at least one provider")
+		for _, name := range names {
+			provider(key, name)
+		}
+	}
+
+	if c.SMTP.Host != "" {
+		v.check(c.SMTP.Port > 0 && c.SMTP.Port <= 65535, "smtp.port", "must be between 1 and 65535")
+		v.oneOf("smtp.tls_mode", c.SMTP.TLSMode, "none", "starttls", "tls")
+		v.check(c.SMTP.PoolSize >= 0, "smtp.pool_size", "must not be negative")
+	}
+}
+
+// validateTokenPolicies는 기본 토큰 정책과, 기본값을 합친 클라이언트별 정책을 검사합니다.
+func (c *Config) validateTokenPolicies(v *validator) {
+	j := c.JWT
+	checkPolicy := func(prefix string, access, refresh, lifetime, idle time.Duration) {
+		v.check(access > 0, prefix+"access_ttl", "must be positive")
+		v.check(refresh > access, prefix+"refresh_ttl", "must be longer than access_ttl")
+		v.check(lifetime >= 0, prefix+"session_lifetime", "must not be negative")
+		v.check(lifetime == 0 || lifetime >= access, prefix+"session_lifetime", "must not be shorter than access_ttl")
+		v.check(idle >= 0, prefix+"idle_timeout", "must not be negative")
+		v.check(idle == 0 || idle >= access, prefix+"idle_timeout", "must not be shorter than access_ttl")
+	}
+	checkPolicy("jwt.", j.AccessTTL, j.RefreshTTL, j.SessionLifetime, j.IdleTimeout)
+
+	ids := slices.Sorted(maps.Keys(j.Clients))
+	for _, id := range ids {
+		o := cmp.Or(j.Clients[id], &JWTClientConfig{}) // 설정 파일에 키만 적은 항목은 nil
+		key := "jwt.clients." + id
+		v.check(clientIDPattern.MatchString(id), key, "client id must be 1-64 lowercase letters, digits, '.', '_' or '-'")
+		v.check(o.Secret != "", key+".secret", "is required (login requests must authenticate with client_secret)")
+		v.check(o.AccessTTL >= 0 && o.RefreshTTL >= 0 && o.SessionLifetime >= 0 && o.IdleTimeout >= 0, key, "durations must not be negative")
+		checkPolicy(key+".",
+			cmp.Or(o.AccessTTL, j.AccessTTL),
+			cmp.Or(o.RefreshTTL, j.RefreshTTL),
+			cmp.Or(o.SessionLifetime, j.SessionLifetime),
+			cmp.Or(o.IdleTimeout, j.IdleTimeout))
+	}
+}
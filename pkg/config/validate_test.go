@@ -0,0 +1,109 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// loadWithFlags는 기본값과 필수 접속 정보에 args 플래그를 적용해 설정을 읽고 검증합니다.
+func loadWithFlags(t *testing.T, args ...string) error {
+	t.Helper()
+	t.Setenv("CONFIG_FILE", "")
+	base := []string{"--database.url=postgres://app@localhost/auth", "--redis.addr=localhost:6379"}
+	_, _, err := LoadConfig(append(base, args...))
+	return err
+}
+
+func TestValidateCORS(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"defaults", nil, ""},
+		{"wildcard without credentials", []string{"--cors.allowed_origins=*"}, ""},
+		{"explicit origins with credentials", []string{"--cors.allowed_origins=https://app.example.com", "--cors.allow_credentials"}, ""},
+		{"wildcard with credentials", []string{"--cors.allowed_origins=https://app.example.com,*", "--cors.allow_credentials"}, "cors.allow_credentials"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadWithFlags(t, tt.args...)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("error = %v, want mention of %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMailProvidersInProduction(t *testing.T) {
+	production := []string{
+		"--environment=production",
+		"--jwt.access_secret=" + strings.Repeat("a", minSecretLength),
+		"--jwt.refresh_secret=" + strings.Repeat("r", minSecretLength),
+		"--smtp.host=smtp.example.com",
+		"--mail.file_dir=/tmp/mail",
+		"--http.port=127.0.0.1:8080",
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"development log", []string{"--mail.primary=log"}, ""},
+		{"development file", []string{"--mail.primary=file", "--mail.file_dir=/tmp/mail"}, ""},
+		{"production smtp", append(production, "--mail.primary=smtp"), ""},
+		{"production log", append(production, "--mail.primary=log"), "mail.primary"},
+		{"production file fallback", append(production, "--mail.primary=smtp", "--mail.secondary=file"), "mail.secondary"},
+		{"production log route", append(production, "--mail.primary=smtp", "--mail.routes=transactional=log"), "mail.routes.transactional"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadWithFlags(t, tt.args...)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr+": provider")):
+				t.Fatalf("error = %v, want %s rejected", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHTTPGatewayTLSInProduction(t *testing.T) {
+	production := []string{
+		"--environment=production",
+		"--jwt.access_secret=" + strings.Repeat("a", minSecretLength),
+		"--jwt.refresh_secret=" + strings.Repeat("r", minSecretLength),
+		"--smtp.host=smtp.example.com",
+		"--mail.primary=smtp",
+	}
+	tls := []string{"--tls.cert_file=/etc/tls/tls.crt", "--tls.key_file=/etc/tls/tls.key"}
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{"development plaintext", []string{"--http.port=:8080"}, false},
+		{"production all interfaces", append(production, "--http.port=:8080"), true},
+		{"production public address", append(production, "--http.port=10.0.0.5:8080"), true},
+		{"production ipv4 loopback", append(production, "--http.port=127.0.0.1:8080"), false},
+		{"production ipv6 loopback", append(production, "--http.port=[::1]:8080"), false},
+		{"production localhost", append(production, "--http.port=localhost:8080"), false},
+		{"production tls", append(append(production, tls...), "--http.port=:8080"), false},
+		{"production gateway disabled", append(production, "--http.port="), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadWithFlags(t, tt.args...)
+			if got := err != nil && strings.Contains(err.Error(), "http.port"); got != tt.wantErr {
+				t.Fatalf("error = %v, want http.port rejected = %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
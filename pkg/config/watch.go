@@ -0,0 +1,295 @@
+// pkg/config/watch.go
+// 이 파일은 설정 파일 변경과 SIGHUP을 감지해 설정을 다시 읽고, 구독자에게 새 설정을 적용하는 과정을 정의합니다.
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+)
+
+// 재로드 계기
+const (
+	TriggerFile   = "file"   // 설정 파일(또는 secrets.file, rate_limit.policy_file) 수정
+	TriggerSignal = "signal" // SIGHUP
+)
+
+// 재로드 결과
+const (
+	reloadSuccess   = "success"
+	reloadRejected  = "rejected"  // 검증 실패, 이전 설정 유지
+	reloadUnchanged = "unchanged" // 설정 값은 그대로 (정책 파일 등 참조 파일만 바뀐 경우 포함)
+)
+
+var (
+	reloadsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "config_reloads_total",
+		Help: "Number of configuration reload attempts by trigger (file, signal) and result (success, rejected, unchanged)",
+	}, []string{"trigger", "result"})
+
+	lastReloadSuccessful = metrics.Factory.NewGauge(prometheus.GaugeOpts{
+		Name: "config_last_reload_successful",
+		Help: "Whether the last configuration reload attempt succeeded (1) or was rejected (0)",
+	})
+
+	lastReloadSuccessTimestamp = metrics.Factory.NewGauge(prometheus.GaugeOpts{
+		Name: "config_last_reload_success_timestamp_seconds",
+		Help: "Unix time of the last successful configuration load",
+	})
+)
+
+func init() {
+	for _, trigger := range []string{TriggerFile, TriggerSignal} {
+		for _, result := range []string{reloadSuccess, reloadRejected, reloadUnchanged} {
+			reloadsTotal.WithLabelValues(trigger, result)
+		}
+	}
+}
+
+// reloadable은 재시작 없이 적용되는 설정 키(또는 "."으로 끝나는 구역)입니다.
+// 그 밖의 키가 바뀌면 이전 값을 유지한 채 경고만 남기며, 재시작해야 반영됩니다.
+var reloadable = []string{
+	"log.level",
+	"rate_limit.rps",
+	"rate_limit.burst",
+	"rate_limit.policy_file",
+	"mail.primary",
+	"mail.secondary",
+	"mail.routes",
+	"jwt.access_ttl",
+	"jwt.refresh_ttl",
+	"jwt.session_lifetime",
+	"jwt.idle_timeout",
+	"jwt.issuer",
+	"jwt.audience",
+	"jwt.clients",
+	"sessions.",
+	"features.",
+}
+
+// Subscriber는 설정이 바뀔 때 새 설정을 받는 구성 요소입니다.
+// 새 설정을 검사해 적용 함수를 반환하며, 에러를 반환하면 재로드 전체가 거부되어
+// 모든 구성 요소가 이전 설정을 유지합니다. 적용 함수는 실패하지 않아야 합니다.
+type Subscriber func(cfg *Config) (apply func(), err error)
+
+type subscription struct {
+	name string
+	fn   Subscriber
+}
+
+// Watcher는 설정 파일을 주기적으로 확인하고 SIGHUP을 받으면 설정을 다시 읽어 구독자에게 적용합니다.
+type Watcher struct {
+	opts Options
+	log  *zap.Logger
+
+	mu      sync.Mutex // 재로드 직렬화
+	subs    []subscription
+	modTime map[string]time.Time
+	current atomic.Pointer[Config]
+}
+
+// NewWatcher는 LoadConfig가 반환한 cfg, opts로 Watcher를 생성합니다.
+func NewWatcher(cfg *Config, opts Options, log *zap.Logger) *Watcher {
+	w := &Watcher{opts: opts, log: log}
+	w.current.Store(cfg)
+	w.modTime = watchedModTimes(cfg, opts)
+	lastReloadSuccessful.Set(1)
+	lastReloadSuccessTimestamp.SetToCurrentTime()
+	return w
+}
+
+// Current는 마지막으로 적용된 설정입니다.
+func (w *Watcher) Current() *Config {
+	return w.current.Load()
+}
+
+// Subscribe는 설정이 바뀔 때마다 호출할 구독자를 등록합니다. 등록 순서대로 호출됩니다.
+func (w *Watcher) Subscribe(name string, fn Subscriber) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs = append(w.subs, subscription{name: name, fn: fn})
+}
+
+// Run은 ctx가 취소될 때까지 SIGHUP과 파일 변경(reload.interval 주기)을 기다려 설정을 다시 읽습니다.
+func (w *Watcher) Run(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval := w.Current().Reload.Interval; interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			_ = w.Reload(TriggerSignal)
+		case <-tick:
+			if w.filesChanged() {
+				_ = w.Reload(TriggerFile)
+			}
+		}
+	}
+}
+
+// Reload는 설정을 다시 읽어 검증하고, 모든 구독자가 받아들이면 적용합니다.
+// 실패하면 에러를 반환하며 이전 설정이 그대로 유지됩니다.
+func (w *Watcher) Reload(trigger string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	changed, restart, err := w.load()
+	if err != nil {
+		reloadsTotal.WithLabelValues(trigger, reloadRejected).Inc()
+		lastReloadSuccessful.Set(0)
+		w.log.Error("config reload rejected, keeping previous config", zap.String("trigger", trigger), zap.Error(err))
+		return err
+	}
+	lastReloadSuccessful.Set(1)
+	if len(changed) == 0 {
+		reloadsTotal.WithLabelValues(trigger, reloadUnchanged).Inc()
+		w.log.Info("config reloaded, no setting changed", zap.String("trigger", trigger))
+		return nil
+	}
+
+	reloadsTotal.WithLabelValues(trigger, reloadSuccess).Inc()
+	lastReloadSuccessTimestamp.SetToCurrentTime()
+	w.log.Info("config reloaded", zap.String("trigger", trigger), zap.Strings("changed", changed))
+	if len(restart) > 0 {
+		w.log.Warn("config changes require restart, keeping previous values until then", zap.Strings("keys", restart))
+	}
+	return nil
+}
+
+// load는 설정을 다시 읽고, 모든 구독자의 검사를 통과한 경우에만 적용합니다.
+// 설정 값이 그대로여도 구독자는 호출되므로 정책 파일처럼 설정이 가리키는 파일의 변경도 반영됩니다.
+// 재시작해야 반영되는 키는 이전 값으로 되돌려 적용하므로 Current는 실제로 사용 중인 설정과 같습니다.
+// 바뀐 키 목록과 그중 재시작이 필요한 키 목록을 반환합니다.
+func (w *Watcher) load() (changed, restart []string, err error) {
+	// 읽기 전에 수정 시각을 기록해, 읽는 도중 파일이 바뀌면 다음 확인에서 다시 로드되도록 함
+	// (거부된 파일을 주기마다 다시 읽지 않도록 실패해도 갱신)
+	w.modTime = watchedModTimes(w.Current(), w.opts)
+
+	next, _, err := LoadConfig(w.opts.args, w.opts.providers...)
+	if err != nil {
+		return nil, nil, err
+	}
+	changed = diffKeys(w.Current(), next)
+	restart = requiresRestart(changed)
+	keepPrevious(w.Current(), next, restart)
+
+	applies := make([]func(), 0, len(w.subs))
+	for _, s := range w.subs {
+		apply, err := s.fn(next)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", s.name, err)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	w.current.Store(next)
+	w.modTime = watchedModTimes(next, w.opts)
+	return changed, restart, nil
+}
+
+// filesChanged는 감시 중인 파일의 수정 시각이 바뀌었는지 확인합니다.
+func (w *Watcher) filesChanged() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for path, t := range w.modTime {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchedModTimes는 설정 파일, 비밀 값 파일, 속도 제한 정책 파일의 수정 시각을 반환합니다.
+func watchedModTimes(cfg *Config, opts Options) map[string]time.Time {
+	modTime := make(map[string]time.Time)
+	for _, path := range []string{opts.File, cfg.Secrets.File, cfg.RateLimit.PolicyFile} {
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err == nil {
+			modTime[path] = info.ModTime()
+		}
+	}
+	return modTime
+}
+
+// diffKeys는 두 설정에서 값이 다른 키 목록을 반환합니다. 비밀 값은 키만 보고합니다.
+func diffKeys(old, next *Config) []string {
+	var keys []string
+	var walk func(a, b reflect.Value, prefix string)
+	walk = func(a, b reflect.Value, prefix string) {
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			key := prefix + t.Field(i).Tag.Get("mapstructure")
+			if a.Field(i).Kind() == reflect.Struct {
+				walk(a.Field(i), b.Field(i), key+".")
+				continue
+			}
+			if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	walk(reflect.ValueOf(old).Elem(), reflect.ValueOf(next).Elem(), "")
+	return keys
+}
+
+// keepPrevious는 next의 keys 설정 값을 old의 값으로 되돌립니다. keys는 diffKeys가 반환한 키입니다.
+func keepPrevious(old, next *Config, keys []string) {
+	for _, key := range keys {
+		a, b := reflect.ValueOf(old).Elem(), reflect.ValueOf(next).Elem()
+		for _, name := range strings.Split(key, ".") {
+			a, b = fieldByTag(a, name), fieldByTag(b, name)
+		}
+		b.Set(a)
+	}
+}
+
+// fieldByTag는 구조체 v에서 mapstructure 태그가 name인 필드를 찾습니다.
+func fieldByTag(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("mapstructure") == name {
+			return v.Field(i)
+		}
+	}
+	panic("config: no field for key " + name)
+}
+
+// requiresRestart는 changed 중 재시작해야 반영되는 키를 반환합니다.
+func requiresRestart(changed []string) []string {
+	var keys []string
+	for _, key := range changed {
+		if !slices.ContainsFunc(reloadable, func(r string) bool {
+			return key == r || (strings.HasSuffix(r, ".") && strings.HasPrefix(key, r))
+		}) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func writeConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcherKeepsNonReloadableValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+grpc: {port: ":50051"}
+database: {url: "postgres://app@db/auth"}
+redis: {addr: "localhost:6379"}
+log: {level: "info"}
+jwt: {access_secret: "old-access-secret"}
+`)
+	cfg, opts, err := LoadConfig([]string{"--config", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWatcher(cfg, opts, zap.NewNop())
+
+	var applied *Config
+	w.Subscribe("test", func(next *Config) (func(), error) {
+		return func() { applied = next }, nil
+	})
+
+	writeConfig(t, path, `
+grpc: {port: ":6000"}
+database: {url: "postgres://app@other-db/auth"}
+redis: {addr: "localhost:6379"}
+log: {level: "debug"}
+jwt: {access_secret: "new-access-secret"}
+`)
+	changed, restart, err := w.load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"grpc.port", "database.url", "jwt.access_secret", "log.level"} {
+		if !slices.Contains(changed, key) {
+			t.Fatalf("changed = %v, missing %s", changed, key)
+		}
+	}
+	if want := []string{"grpc.port", "database.url", "jwt.access_secret"}; !slices.Equal(sorted(restart), sorted(want)) {
+		t.Fatalf("restart = %v, want %v", restart, want)
+	}
+
+	cur := w.Current()
+	if cur.Log.Level != "debug" {
+		t.Fatalf("reloadable log.level = %q, want debug", cur.Log.Level)
+	}
+	if cur.GRPC.Port != ":50051" || cur.Database.URL != "postgres://app@db/auth" || cur.JWT.AccessSecret != "old-access-secret" {
+		t.Fatalf("non-reloadable values replaced: port %q, db %q, secret %q", cur.GRPC.Port, cur.Database.URL, cur.JWT.AccessSecret)
+	}
+	if applied != cur {
+		t.Fatal("subscribers did not receive the stored config")
+	}
+}
+
+func sorted(s []string) []string {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
@@ -0,0 +1,139 @@
+// pkg/lifecycle/lifecycle.go
+// 이 파일은 서버 구성 요소의 실행과 종료 순서를 관리하는 라이프사이클 매니저를 정의합니다.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// stopHook은 종료 시 실행할 함수입니다.
+type stopHook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Manager는 백그라운드 구성 요소를 실행하고, 종료 신호(SIGINT/SIGTERM)나
+// 구성 요소 오류가 발생하면 등록된 종료 훅을 등록 순서대로 실행합니다.
+//
+// 모든 종료 훅은 하나의 종료 타임아웃을 공유합니다. 앞선 훅이 타임아웃을
+// 모두 사용하면 뒤의 훅은 이미 만료된 ctx를 받으므로, 훅은 ctx 만료 시
+// 강제 종료로 전환해야 합니다.
+type Manager struct {
+	log     *zap.Logger
+	timeout time.Duration
+
+	mu    sync.Mutex
+	hooks []stopHook
+
+	once   sync.Once
+	done   chan struct{} // 종료 시작 시 닫힘
+	reason error         // 종료 원인 (구성 요소 오류, 신호는 nil)
+}
+
+// New 생성자 함수는 timeout 안에 종료를 마치도록 하는 Manager를 생성합니다.
+func New(log *zap.Logger, timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &Manager{log: log, timeout: timeout, done: make(chan struct{})}
+}
+
+// Go는 fn을 고루틴으로 실행합니다. fn이 오류를 반환하면 서버 전체 종료를 시작합니다.
+// fn은 종료 훅에 의해 멈춘 경우 nil을 반환해야 합니다.
+func (m *Manager) Go(name string, fn func() error) {
+	go func() {
+		if err := fn(); err != nil {
+			m.log.Error("component failed", zap.String("component", name), zap.Error(err))
+			m.trigger(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// OnStop은 종료 시 실행할 훅을 등록합니다. 훅은 등록한 순서대로 실행됩니다.
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	m.hooks = append(m.hooks, stopHook{name: name, stop: stop})
+	m.mu.Unlock()
+}
+
+// Delay는 d 동안 기다리는 종료 훅을 반환합니다. ctx가 만료되면 즉시 반환합니다.
+// 헬스 상태 전환 뒤에 등록해 로드밸런서가 인스턴스를 제외할 시간을 확보하는 데 사용합니다.
+func Delay(d time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		t := time.NewTimer(d)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+		}
+		return nil
+	}
+}
+
+// Shutdown은 신호 없이 종료를 시작합니다.
+func (m *Manager) Shutdown() {
+	m.trigger(nil)
+}
+
+func (m *Manager) trigger(reason error) {
+	m.once.Do(func() {
+		m.reason = reason
+		close(m.done)
+	})
+}
+
+// Wait는 종료 신호, 구성 요소 오류, Shutdown 호출 중 하나를 기다린 뒤 모든 종료 훅을 실행합니다.
+// 종료 원인이 된 구성 요소 오류와 훅 오류를 합쳐 반환합니다.
+func (m *Manager) Wait() error {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	select {
+	case sig := <-sigCh:
+		m.log.Info("shutdown signal received", zap.String("signal", sig.String()))
+		m.trigger(nil)
+	case <-m.done:
+	}
+
+	// 종료 중 두 번째 신호를 받으면 즉시 종료
+	go func() {
+		if sig, ok := <-sigCh; ok {
+			m.log.Warn("second signal received, exiting immediately", zap.String("signal", sig.String()))
+			os.Exit(1)
+		}
+	}()
+
+	return errors.Join(m.reason, m.stop())
+}
+
+// stop은 종료 훅을 등록 순서대로 실행합니다.
+func (m *Manager) stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	hooks := append([]stopHook(nil), m.hooks...)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, h := range hooks {
+		start := time.Now()
+		if err := h.stop(ctx); err != nil {
+			m.log.Error("shutdown step failed", zap.String("step", h.name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		m.log.Info("shutdown step completed", zap.String("step", h.name), zap.Duration("elapsed", time.Since(start)))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,156 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestDelayWaits(t *testing.T) {
+	start := time.Now()
+	if err := Delay(50 * time.Millisecond)(context.Background()); err != nil {
+		t.Fatalf("Delay = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("Delay returned after %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestDelayStopsOnContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := Delay(time.Minute)(ctx); err != nil {
+		t.Fatalf("Delay = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Delay returned after %v, ctx expiry was ignored", elapsed)
+	}
+}
+
+// waitFor는 Wait를 실행하고, 제한 시간 안에 끝나지 않으면 테스트를 실패시킵니다.
+func waitFor(t *testing.T, m *Manager) error {
+	t.Helper()
+	result := make(chan error, 1)
+	go func() { result <- m.Wait() }()
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait did not return")
+		return nil
+	}
+}
+
+func TestHooksRunInRegistrationOrder(t *testing.T) {
+	m := New(zap.NewNop(), time.Second)
+	var order []string
+	for _, name := range []string{"health", "grpc server", "redis"} {
+		m.OnStop(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	m.Shutdown()
+	if err := waitFor(t, m); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+	// main은 헬스 상태 전환 → 서버 종료 → 저장소 해제 순서로 등록하므로 등록 순서대로 실행되어야 함
+	if want := []string{"health", "grpc server", "redis"}; !slices.Equal(order, want) {
+		t.Fatalf("hooks ran in order %v, want %v", order, want)
+	}
+}
+
+func TestFailingComponentTriggersShutdown(t *testing.T) {
+	m := New(zap.NewNop(), time.Second)
+	stopped := false
+	m.OnStop("server", func(context.Context) error {
+		stopped = true
+		return nil
+	})
+
+	boom := errors.New("listener closed")
+	m.Go("grpc server", func() error { return boom })
+	err := waitFor(t, m)
+	if !errors.Is(err, boom) || !strings.Contains(err.Error(), "grpc server: listener closed") {
+		t.Fatalf("Wait = %v, want the component error", err)
+	}
+	if !stopped {
+		t.Fatal("stop hooks did not run after the component failed")
+	}
+}
+
+func TestComponentReturningNilDoesNotTriggerShutdown(t *testing.T) {
+	m := New(zap.NewNop(), time.Second)
+	finished := make(chan struct{})
+	m.Go("worker", func() error {
+		defer close(finished)
+		return nil
+	})
+	<-finished
+
+	select {
+	case <-m.done:
+		t.Fatal("shutdown started after a component returned nil")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestHookErrorsAreJoined(t *testing.T) {
+	m := New(zap.NewNop(), time.Second)
+	errGRPC := errors.New("grpc stop failed")
+	errRedis := errors.New("redis close failed")
+	ran := 0
+	m.OnStop("grpc server", func(context.Context) error { ran++; return errGRPC })
+	m.OnStop("mailer", func(context.Context) error { ran++; return nil })
+	m.OnStop("redis", func(context.Context) error { ran++; return errRedis })
+
+	m.Shutdown()
+	err := waitFor(t, m)
+	// 실패한 훅이 있어도 나머지 훅은 계속 실행
+	if ran != 3 {
+		t.Fatalf("%d hooks ran, want 3", ran)
+	}
+	if !errors.Is(err, errGRPC) || !errors.Is(err, errRedis) {
+		t.Fatalf("Wait = %v, want both hook errors", err)
+	}
+	for _, want := range []string{"grpc server: grpc stop failed", "redis: redis close failed"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("Wait = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestSharedTimeoutCancelsHangingHook(t *testing.T) {
+	m := New(zap.NewNop(), 50*time.Millisecond)
+	m.OnStop("hanging", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	// 앞선 훅이 타임아웃을 모두 사용하면 뒤의 훅은 만료된 ctx를 받음
+	var laterErr error
+	m.OnStop("later", func(ctx context.Context) error {
+		laterErr = ctx.Err()
+		return nil
+	})
+
+	start := time.Now()
+	m.Shutdown()
+	err := waitFor(t, m)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("shutdown took %v, want about the 50ms timeout", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "hanging") {
+		t.Fatalf("Wait = %v, want the hanging hook's deadline error", err)
+	}
+	if !errors.Is(laterErr, context.DeadlineExceeded) {
+		t.Fatalf("later hook ctx err = %v, want DeadlineExceeded", laterErr)
+	}
+}
@@ -0,0 +1,66 @@
+// pkg/logger/context.go
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type (
+	requestIDKey struct{}
+	clientIPKey  struct{}
+)
+
+// WithRequestID는 요청 ID를 담은 컨텍스트를 반환합니다.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext는 컨텍스트의 요청 ID를 반환합니다 (없으면 빈 문자열).
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithClientIP는 신뢰하는 프록시 설정에 따라 확인한 클라이언트 IP를 담은 컨텍스트를 반환합니다.
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIPFromContext는 컨텍스트의 클라이언트 IP를 반환합니다 (없으면 빈 문자열).
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// Ctx는 base 로거에 컨텍스트의 요청 ID, 클라이언트 IP와 트레이스 ID를 필드로 추가한 로거를 반환합니다.
+// base가 nil이면 전역 로거(zap.L())를 사용합니다.
+//
+//	logger.Ctx(ctx, s.log).Error("SignUp failed", zap.Error(err))
+func Ctx(ctx context.Context, base *zap.Logger) *zap.Logger {
+	if base == nil {
+		base = zap.L()
+	}
+	var fields []zap.Field
+	if id := RequestIDFromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if ip := ClientIPFromContext(ctx); ip != "" {
+		fields = append(fields, zap.String("client_ip", ip))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields, zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+	}
+	if len(fields) == 0 {
+		return base
+	}
+	return base.With(fields...)
+}
+
+// FromContext는 전역 로거에 컨텍스트의 요청 ID를 추가한 로거를 반환합니다.
+// 로거를 주입받지 않는 패키지에서 사용합니다.
+func FromContext(ctx context.Context) *zap.Logger {
+	return Ctx(ctx, nil)
+}
@@ -8,12 +8,23 @@ import (
 	"path/filepath"
 )
 
+// atomicLevel은 NewLogger로 만든 로거가 공유하는 로그 레벨입니다 (SetLevel로 실행 중 변경).
+var atomicLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+// SetLevel은 NewLogger로 만든 로거의 레벨을 재시작 없이 바꿉니다.
+func SetLevel(level string) error {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	atomicLevel.SetLevel(lvl)
+	return nil
+}
+
 func NewLogger(level string) *zap.Logger {
 	cfg := zap.NewProductionConfig()
-	lvl := zapcore.InfoLevel
-	if err := lvl.UnmarshalText([]byte(level)); err == nil {
-		cfg.Level = zap.NewAtomicLevelAt(lvl)
-	}
+	_ = SetLevel(level) // 알 수 없는 레벨이면 info 유지
+	cfg.Level = atomicLevel
 
 	// 사용자 홈 디렉토리에 로그 저장
 	homeDir, _ := os.UserHomeDir()
@@ -0,0 +1,62 @@
+// pkg/logger/redact.go
+package logger
+
+import (
+	"regexp"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?[0-9][0-9\- ]{7,}[0-9]`)
+)
+
+// RedactEmail은 이메일 로컬 파트의 첫 글자만 남기고 가립니다 (john@example.com → j***@example.com).
+func RedactEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+// RedactPhone은 전화번호의 마지막 4자리만 남기고 가립니다 (+821012345678 → +*********5678).
+func RedactPhone(phone string) string {
+	if len(phone) <= 4 {
+		return "***"
+	}
+	prefix := ""
+	if strings.HasPrefix(phone, "+") {
+		prefix, phone = "+", phone[1:]
+	}
+	if len(phone) <= 4 {
+		return prefix + "***"
+	}
+	return prefix + strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
+
+// Redact는 자유 형식 문자열(에러 메시지 등)에 포함된 이메일과 전화번호를 가립니다.
+func Redact(s string) string {
+	s = emailPattern.ReplaceAllStringFunc(s, RedactEmail)
+	return phonePattern.ReplaceAllStringFunc(s, RedactPhone)
+}
+
+// Email은 가린 이메일을 담은 로그 필드를 생성합니다.
+func Email(key, email string) zap.Field {
+	return zap.String(key, RedactEmail(email))
+}
+
+// Phone은 가린 전화번호를 담은 로그 필드를 생성합니다.
+func Phone(key, phone string) zap.Field {
+	return zap.String(key, RedactPhone(phone))
+}
+
+// RedactedError는 메시지의 개인정보를 가린 에러 로그 필드를 생성합니다.
+func RedactedError(err error) zap.Field {
+	if err == nil {
+		return zap.Skip()
+	}
+	return zap.String("error", Redact(err.Error()))
+}
@@ -0,0 +1,102 @@
+// pkg/metrics/histogram.go
+// 이 파일은 설정 가능한 버킷과 네이티브 히스토그램, exemplar를 지원하는 지연 시간 히스토그램을 정의합니다.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HistogramConfig는 지연 시간 히스토그램 설정입니다.
+type HistogramConfig struct {
+	Buckets []float64 // 클래식 히스토그램 버킷 (비어 있으면 prometheus.DefBuckets)
+	Native  bool      // 네이티브(지수) 히스토그램도 함께 기록 (protobuf 형식으로 수집할 때만 노출)
+}
+
+var (
+	histogramMu  sync.Mutex
+	histogramCfg = HistogramConfig{Buckets: prometheus.DefBuckets}
+)
+
+// ConfigureHistograms는 지연 시간 히스토그램 설정을 바꿉니다.
+// 히스토그램은 처음 기록할 때 생성되므로 서버 시작 전에 호출해야 합니다.
+func ConfigureHistograms(cfg HistogramConfig) {
+	if len(cfg.Buckets) == 0 {
+		cfg.Buckets = prometheus.DefBuckets
+	}
+	histogramMu.Lock()
+	defer histogramMu.Unlock()
+	histogramCfg = cfg
+}
+
+// LatencyHistogram은 초 단위 지연 시간 히스토그램입니다.
+// 패키지 변수로 선언해도 ConfigureHistograms 설정이 적용되도록 처음 기록할 때 생성해 Registry에 등록합니다.
+type LatencyHistogram struct {
+	name   string
+	help   string
+	labels []string
+
+	once sync.Once
+	vec  *prometheus.HistogramVec
+}
+
+// NewLatencyHistogram은 지연 시간 히스토그램을 선언합니다.
+func NewLatencyHistogram(name, help string, labels ...string) *LatencyHistogram {
+	return &LatencyHistogram{name: name, help: help, labels: labels}
+}
+
+func (h *LatencyHistogram) init() {
+	histogramMu.Lock()
+	cfg := histogramCfg
+	histogramMu.Unlock()
+
+	opts := prometheus.HistogramOpts{
+		Name:    h.name,
+		Help:    h.help,
+		Buckets: cfg.Buckets,
+	}
+	if cfg.Native {
+		opts.NativeHistogramBucketFactor = 1.1 // 버킷 경계 간 최대 10% 차이
+		opts.NativeHistogramMaxBucketNumber = 160
+		opts.NativeHistogramMinResetDuration = time.Hour
+	}
+	h.vec = Factory.NewHistogramVec(opts, h.labels)
+}
+
+// Observe는 seconds를 기록합니다.
+// ctx에 샘플링된 스팬이나 요청 ID가 있으면 exemplar로 첨부해 대시보드에서 해당 트레이스/로그로 이동할 수 있게 합니다.
+func (h *LatencyHistogram) Observe(ctx context.Context, seconds float64, labelValues ...string) {
+	h.once.Do(h.init)
+	obs := h.vec.WithLabelValues(labelValues...)
+
+	exemplar := exemplarLabels(ctx)
+	if eo, ok := obs.(prometheus.ExemplarObserver); ok && exemplar != nil {
+		eo.ObserveWithExemplar(seconds, exemplar)
+		return
+	}
+	obs.Observe(seconds)
+}
+
+// exemplarLabels는 ctx의 트레이스 ID와 요청 ID로 exemplar 라벨을 만듭니다 (없으면 nil).
+func exemplarLabels(ctx context.Context) prometheus.Labels {
+	labels := prometheus.Labels{}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() && sc.IsSampled() {
+		labels["trace_id"] = sc.TraceID().String()
+	}
+	if id := logger.RequestIDFromContext(ctx); id != "" {
+		// exemplar 라벨은 이름과 값을 합쳐 128자를 넘으면 panic이 발생하므로 잘라서 사용 (trace_id 포함 최대 114자)
+		if len(id) > 64 {
+			id = id[:64]
+		}
+		labels["request_id"] = id
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withSpan은 sampled 여부가 정해진 스팬 컨텍스트를 ctx에 담습니다.
+func withSpan(ctx context.Context, sampled bool) context.Context {
+	cfg := trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	}
+	if sampled {
+		cfg.TraceFlags = trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(cfg))
+}
+
+func TestExemplarLabels(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	long := strings.Repeat("r", 128) // 요청 ID 인터셉터가 허용하는 최대 길이
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]string // nil이면 exemplar 없음
+	}{
+		{"empty context", context.Background(), nil},
+		{"unsampled span", withSpan(context.Background(), false), nil},
+		{"sampled span", withSpan(context.Background(), true), map[string]string{"trace_id": traceID}},
+		{"request id", logger.WithRequestID(context.Background(), "req-1"), map[string]string{"request_id": "req-1"}},
+		{"long request id truncated", logger.WithRequestID(context.Background(), long), map[string]string{"request_id": long[:64]}},
+		{"trace and request id", logger.WithRequestID(withSpan(context.Background(), true), long), map[string]string{"trace_id": traceID, "request_id": long[:64]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exemplarLabels(tt.ctx)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("labels = %v, want none", got)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("labels = %v, want %v", got, tt.want)
+			}
+			length := 0
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("%s = %q, want %q", k, got[k], v)
+				}
+				length += len(k) + len(v)
+			}
+			// Prometheus는 exemplar 라벨 이름과 값의 합이 128자를 넘으면 거부
+			if length > 128 {
+				t.Fatalf("exemplar labels use %d characters, limit is 128", length)
+			}
+		})
+	}
+}
+
+func TestObserveAttachesExemplar(t *testing.T) {
+	h := NewLatencyHistogram("test_exemplar_duration_seconds", "Histogram used by the exemplar test", "method")
+	ctx := logger.WithRequestID(withSpan(context.Background(), true), strings.Repeat("r", 128))
+
+	// 긴 요청 ID와 트레이스 ID를 함께 기록해도 라벨 길이 제한으로 panic이 발생하지 않아야 함
+	h.Observe(ctx, 0.02, "/auth.AuthService/Login")
+
+	families, err := Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != "test_exemplar_duration_seconds" {
+			continue
+		}
+		for _, b := range mf.GetMetric()[0].GetHistogram().GetBucket() {
+			if ex := b.GetExemplar(); ex != nil {
+				labels := map[string]string{}
+				for _, l := range ex.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || labels["request_id"] != strings.Repeat("r", 64) {
+					t.Fatalf("exemplar labels = %v", labels)
+				}
+				return
+			}
+		}
+		t.Fatal("no exemplar recorded")
+	}
+	t.Fatal("histogram not registered")
+}
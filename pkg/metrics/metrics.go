@@ -0,0 +1,42 @@
+// pkg/metrics/metrics.go
+// 이 파일은 서비스 전용 Prometheus 레지스트리와 /metrics HTTP 핸들러를 정의합니다.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry는 서비스의 모든 메트릭을 등록하는 레지스트리입니다.
+// 전역 prometheus.DefaultRegisterer 대신 사용하므로 라이브러리가 임의로 등록한 메트릭은 노출되지 않습니다.
+var Registry = prometheus.NewRegistry()
+
+// Factory는 Registry에 등록하는 promauto 팩토리입니다.
+//
+//	var loginsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{...}, []string{"outcome"})
+var Factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		// Go 런타임 (GC, 메모리, 스케줄러) 및 프로세스 (CPU, 메모리, 파일 디스크립터) 메트릭
+		collectors.NewGoCollector(collectors.WithGoCollectorRuntimeMetrics(
+			collectors.MetricsGC,
+			collectors.MetricsMemory,
+			collectors.MetricsScheduler,
+		)),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler는 Registry의 메트릭을 노출하는 HTTP 핸들러를 반환합니다.
+// exemplar(트레이스 ID, 요청 ID) 노출을 위해 OpenMetrics 형식을 허용합니다.
+func Handler() http.Handler {
+	return promhttp.InstrumentMetricHandler(Registry, promhttp.HandlerFor(Registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+		Registry:          Registry, // 수집 에러 카운터 등록
+	}))
+}
@@ -0,0 +1,57 @@
+// pkg/secrets/file_store.go
+// 이 파일은 로컬 YAML 파일에 비밀 값을 보관하는 Provider 구현을 정의합니다.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemeLocal은 FileStore를 가리키는 참조의 스킴입니다 (예: local://jwt_access_secret).
+const SchemeLocal = "local"
+
+// FileStore는 "이름: 값" 형식의 YAML 파일을 읽어 비밀 값을 제공하는 저장소입니다.
+// 외부 저장소 없이 개발/단일 서버 환경에서 비밀 값을 설정 파일과 분리할 때 사용합니다.
+//
+//	# secrets.yaml (권한 0600 권장)
+//	jwt_access_secret: "..."
+//	smtp_pass: "..."
+type FileStore struct {
+	path   string
+	values map[string]string
+}
+
+// NewFileStore는 path의 YAML 파일을 읽어 FileStore를 생성합니다.
+// 파일을 다른 사용자가 읽을 수 있으면 에러를 반환합니다.
+func NewFileStore(path string) (*FileStore, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("secret store: %w", err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("secret store: %s is accessible by other users (mode %v), use 0600", path, info.Mode().Perm())
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("secret store: %w", err)
+	}
+	values := make(map[string]string)
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("secret store: parse %s: %w", path, err)
+	}
+	return &FileStore{path: path, values: values}, nil
+}
+
+func (s *FileStore) Scheme() string { return SchemeLocal }
+
+// Lookup은 name의 값을 반환합니다.
+func (s *FileStore) Lookup(_ context.Context, name string) (string, error) {
+	value, ok := s.values[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s has no entry %q", ErrNotFound, s.path, name)
+	}
+	return value, nil
+}
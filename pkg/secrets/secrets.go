@@ -0,0 +1,128 @@
+// pkg/secrets/secrets.go
+// 이 파일은 설정 값에 적힌 비밀 참조(file://, env://, 외부 저장소)를 실제 값으로 바꾸는 방법을 정의합니다.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNotFound는 참조한 비밀이 저장소에 없을 때 반환됩니다.
+var ErrNotFound = errors.New("secret not found")
+
+// Provider는 비밀 값을 보관하는 외부 저장소(Vault, 클라우드 Secret Manager 등)입니다.
+// "<scheme>://<name>" 형식의 참조를 Lookup(name)으로 조회합니다.
+type Provider interface {
+	// Scheme은 이 저장소를 가리키는 참조의 스킴입니다 (예: "vault").
+	Scheme() string
+	// Lookup은 name의 값을 반환합니다. 없으면 ErrNotFound를 감싼 에러를 반환합니다.
+	Lookup(ctx context.Context, name string) (string, error)
+}
+
+// 기본으로 지원하는 스킴
+const (
+	SchemeFile = "file" // file:///run/secrets/smtp_pass - 파일 내용 (Docker/Kubernetes secret)
+	SchemeEnv  = "env"  // env://SMTP_PASSWORD - 다른 환경 변수의 값
+)
+
+// Resolver는 스킴별 저장소로 비밀 참조를 해석합니다.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// NewResolver는 file, env 스킴과 providers를 지원하는 Resolver를 생성합니다.
+// 같은 스킴이 여러 번 있으면 나중 것이 사용됩니다.
+func NewResolver(providers ...Provider) *Resolver {
+	r := &Resolver{providers: make(map[string]Provider)}
+	r.Register(fileProvider{})
+	r.Register(envProvider{})
+	for _, p := range providers {
+		r.Register(p)
+	}
+	return r
+}
+
+// Register는 p의 스킴으로 시작하는 참조를 p로 조회하도록 등록합니다.
+func (r *Resolver) Register(p Provider) {
+	r.providers[p.Scheme()] = p
+}
+
+// IsReference는 value가 등록된 스킴의 참조인지 확인합니다.
+func (r *Resolver) IsReference(value string) bool {
+	scheme, _, ok := strings.Cut(value, "://")
+	if !ok {
+		return false
+	}
+	_, ok = r.providers[scheme]
+	return ok
+}
+
+// Resolve는 value가 참조이면 저장소에서 조회한 값을, 아니면 value를 그대로 반환합니다.
+// 등록되지 않은 스킴(예: postgres://)은 참조로 보지 않습니다.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, name, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+	p, ok := r.providers[scheme]
+	if !ok {
+		return value, nil
+	}
+	if name == "" {
+		return "", fmt.Errorf("secret reference %q: missing name", value)
+	}
+	secret, err := p.Lookup(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s secret %q: %w", scheme, name, err)
+	}
+	return secret, nil
+}
+
+// fileProvider는 파일 내용을 비밀 값으로 사용합니다. 끝의 줄바꿈은 제거합니다.
+type fileProvider struct{}
+
+func (fileProvider) Scheme() string { return SchemeFile }
+
+func (fileProvider) Lookup(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// envProvider는 다른 환경 변수의 값을 비밀 값으로 사용합니다.
+type envProvider struct{}
+
+func (envProvider) Scheme() string { return SchemeEnv }
+
+func (envProvider) Lookup(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrNotFound, name)
+	}
+	return value, nil
+}
+
+// Unavailable은 scheme의 참조를 항상 거부하는 Provider를 반환합니다.
+// 저장소가 설정되지 않았을 때 참조 문자열이 그대로 비밀 값으로 쓰이지 않도록 등록합니다.
+func Unavailable(scheme, reason string) Provider {
+	return unavailableProvider{scheme: scheme, reason: reason}
+}
+
+type unavailableProvider struct {
+	scheme string
+	reason string
+}
+
+func (p unavailableProvider) Scheme() string { return p.scheme }
+
+func (p unavailableProvider) Lookup(context.Context, string) (string, error) {
+	return "", errors.New(p.reason)
+}
@@ -3,10 +3,12 @@
 package token
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
-	"os"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,24 +16,102 @@ import (
 
 // JWTClaims 커스텀 클레임 구조
 type JWTClaims struct {
-	UserID  string `json:"sub"`
-	TokenID string `json:"jti,omitempty"` // JWT ID 추가
+	UserID    string           `json:"sub"`
+	TokenID   string           `json:"jti,omitempty"`       // JWT ID 추가
+	ClientID  string           `json:"client_id,omitempty"` // 로그인한 클라이언트 (정책 선택에 사용)
+	AuthTime  *jwt.NumericDate `json:"auth_time,omitempty"` // 로그인 시각 (토큰을 갱신해도 유지)
+	SessionID string           `json:"sid,omitempty"`       // 로그인 세션 ID (토큰을 갱신해도 유지, 세션 종료 시 액세스 토큰 폐기에 사용)
 	jwt.RegisteredClaims
 }
 
-// 환경 변수에서 시크릿 로드
-var (
-	accessSecret  = []byte(getEnv("JWT_ACCESS_SECRET", "access-secret-key"))
-	refreshSecret = []byte(getEnv("JWT_REFRESH_SECRET", "refresh-secret-key"))
-)
+// Session은 토큰을 발급하는 로그인 세션입니다.
+type Session struct {
+	ID       string // 세션 ID (NewSessionID로 로그인 시 생성)
+	UserID   string
+	ClientID string
+	AuthTime time.Time // 로그인 시각, 세션 수명의 기준
+}
+
+// NewSessionID는 새 로그인 세션의 ID를 생성합니다.
+func NewSessionID() (string, error) {
+	return generateTokenID()
+}
 
-func getEnv(key, fallback string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
+// SessionFromClaims는 리프레시 토큰 클레임이 가리키는 세션입니다. 토큰 회전 시 세션 정보를 이어받는 데 사용합니다.
+// auth_time이 없는 (정책 도입 전에 발급된) 토큰은 발급 시각을 로그인 시각으로 봅니다.
+// sid가 없는 토큰의 세션 ID는 비어 있습니다.
+func SessionFromClaims(claims *JWTClaims) Session {
+	s := Session{ID: claims.SessionID, UserID: claims.UserID, ClientID: claims.ClientID}
+	switch {
+	case claims.AuthTime != nil:
+		s.AuthTime = claims.AuthTime.Time
+	case claims.IssuedAt != nil:
+		s.AuthTime = claims.IssuedAt.Time
 	}
-	return fallback
+	return s
+}
+
+// Issued는 발급한 토큰입니다.
+type Issued struct {
+	Token     string
+	ID        string    // 토큰 ID (jti, 리프레시 토큰만)
+	ExpiresAt time.Time // 정책에 따라 정해진 만료 시각
+}
+
+// RevocationList는 종료된 세션 목록입니다. 종료된 세션의 액세스 토큰은 만료 전이라도 거부합니다.
+type RevocationList interface {
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+}
+
+// ErrSessionRevoked는 액세스 토큰의 세션이 종료되었을 때 반환됩니다.
+var ErrSessionRevoked = errors.New("session revoked")
+
+// Issuer는 서명 키와 발급 정책으로 액세스/리프레시 토큰을 발급하고 검증합니다.
+// 서명 키는 생성 시 정해지고, 정책은 SetPolicies로 실행 중 교체할 수 있습니다 (설정 재로드).
+type Issuer struct {
+	accessSecret  []byte
+	refreshSecret []byte
+	policies      atomic.Pointer[Policies]
+	revoked       RevocationList
+}
+
+// NewIssuer 생성자 함수는 액세스/리프레시 토큰 서명 키와 발급 정책으로 Issuer를 생성합니다.
+// revoked가 nil이면 액세스 토큰 검증 시 세션 종료 여부를 확인하지 않습니다.
+func NewIssuer(accessSecret, refreshSecret []byte, policies Policies, revoked RevocationList) *Issuer {
+	i := &Issuer{accessSecret: accessSecret, refreshSecret: refreshSecret, revoked: revoked}
+	i.policies.Store(&policies)
+	return i
+}
+
+// SetPolicies는 이후 발급, 검증에 사용할 정책을 교체합니다. 이미 발급된 토큰의 만료 시각에는 영향이 없습니다.
+func (i *Issuer) SetPolicies(p Policies) {
+	i.policies.Store(&p)
+}
+
+// PolicyFor는 현재 설정에서 clientID에 적용할 정책입니다.
+func (i *Issuer) PolicyFor(clientID string) Policy {
+	return i.policies.Load().For(clientID)
+}
+
+// MaxAccessTTL은 현재 설정의 기본 정책과 클라이언트별 정책 중 가장 긴 액세스 토큰 유효 기간입니다.
+// 종료한 세션의 액세스 토큰을 이 기간 동안 거부하면 이미 발급된 토큰이 모두 만료됩니다.
+func (i *Issuer) MaxAccessTTL() time.Duration {
+	p := i.policies.Load()
+	ttl := p.Default.AccessTTL
+	for _, c := range p.Clients {
+		ttl = max(ttl, c.AccessTTL)
+	}
+	return ttl
+}
+
+// AuthenticateClient는 현재 설정으로 클라이언트를 인증합니다.
+func (i *Issuer) AuthenticateClient(clientID, secret string) error {
+	return i.policies.Load().Authenticate(clientID, secret)
 }
 
+// validMethods는 검증 시 허용하는 서명 알고리즘입니다. 발급에 쓰는 HS256 외의 알고리즘(none 등)은 거부합니다.
+var validMethods = []string{jwt.SigningMethodHS256.Alg()}
+
 // 고유 토큰 ID 생성
 func generateTokenID() (string, error) {
 	b := make([]byte, 16)
@@ -42,52 +122,91 @@ func generateTokenID() (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
-// Access Token: 15분 유효
-func GenerateAccessToken(userID string) (string, error) {
+// claimsFor는 세션과 정책으로 공통 클레임을 만듭니다.
+func claimsFor(s Session, p Policy, now, expiresAt time.Time) JWTClaims {
 	claims := JWTClaims{
-		UserID: userID,
+		UserID:   s.UserID,
+		ClientID: s.ClientID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    p.Issuer,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if len(p.Audience) > 0 {
+		claims.Audience = p.Audience
+	}
+	if !s.AuthTime.IsZero() {
+		claims.AuthTime = jwt.NewNumericDate(s.AuthTime)
+	}
+	claims.SessionID = s.ID
+	return claims
+}
+
+// Access Token: 클라이언트 정책의 AccessTTL 동안 유효 (세션 종료 시각을 넘지 않음)
+func (i *Issuer) GenerateAccessToken(s Session) (Issued, error) {
+	p := i.PolicyFor(s.ClientID)
+	now := time.Now()
+	expiresAt := p.expiry(now, s.AuthTime, p.AccessTTL)
+	claims := claimsFor(s, p, now, expiresAt)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(accessSecret)
+	signedToken, err := token.SignedString(i.accessSecret)
+	if err != nil {
+		return Issued{}, err
+	}
+	return Issued{Token: signedToken, ExpiresAt: expiresAt}, nil
 }
 
-// Refresh Token: 7일 유효, 고유 ID 포함
-func GenerateRefreshToken(userID string) (string, string, error) {
+// Refresh Token: 클라이언트 정책의 RefreshTTL 동안 유효, 고유 ID 포함
+// IdleTimeout이 더 짧으면 그만큼만, 세션 종료 시각을 넘지 않도록 유효합니다.
+func (i *Issuer) GenerateRefreshToken(s Session) (Issued, error) {
 	tokenID, err := generateTokenID()
 	if err != nil {
-		return "", "", err
+		return Issued{}, err
 	}
 
-	claims := JWTClaims{
-		UserID:  userID,
-		TokenID: tokenID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(7 * 24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	p := i.PolicyFor(s.ClientID)
+	ttl := p.RefreshTTL
+	if p.IdleTimeout > 0 && p.IdleTimeout < ttl {
+		ttl = p.IdleTimeout
 	}
+	now := time.Now()
+	expiresAt := p.expiry(now, s.AuthTime, ttl)
+	claims := claimsFor(s, p, now, expiresAt)
+	claims.TokenID = tokenID
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(refreshSecret)
+	signedToken, err := token.SignedString(i.refreshSecret)
 	if err != nil {
-		return "", "", err
+		return Issued{}, err
 	}
 
-	return signedToken, tokenID, nil
+	return Issued{Token: signedToken, ID: tokenID, ExpiresAt: expiresAt}, nil
 }
 
-// Access Token 검증
-func ValidateAccessToken(tokenString string) (*JWTClaims, error) {
+// checkPolicy는 토큰의 iss, aud 클레임이 클라이언트 정책과 맞는지 확인합니다.
+func (i *Issuer) checkPolicy(claims *JWTClaims) error {
+	p := i.PolicyFor(claims.ClientID)
+	if p.Issuer != "" && claims.Issuer != p.Issuer {
+		return errors.New("invalid token issuer")
+	}
+	if len(p.Audience) > 0 && !slices.ContainsFunc(p.Audience, func(aud string) bool {
+		return slices.Contains(claims.Audience, aud)
+	}) {
+		return errors.New("invalid token audience")
+	}
+	return nil
+}
+
+// Access Token 검증 - 서명, 만료, 정책에 더해 세션이 종료되지 않았는지 확인합니다.
+// 세션 종료 여부를 확인할 수 없으면 에러를 반환합니다.
+func (i *Issuer) ValidateAccessToken(ctx context.Context, tokenString string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return accessSecret, nil
-	})
+		return i.accessSecret, nil
+	}, jwt.WithValidMethods(validMethods))
 
 	if err != nil {
 		return nil, err
@@ -97,16 +216,29 @@ func ValidateAccessToken(tokenString string) (*JWTClaims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if err := i.checkPolicy(claims); err != nil {
+		return nil, err
+	}
+
+	if i.revoked != nil && claims.SessionID != "" {
+		revoked, err := i.revoked.IsSessionRevoked(ctx, claims.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrSessionRevoked
+		}
+	}
 	return claims, nil
 }
 
 // Refresh Token 검증
-func ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
+func (i *Issuer) ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return refreshSecret, nil
-	})
+		return i.refreshSecret, nil
+	}, jwt.WithValidMethods(validMethods))
 
 	if err != nil {
 		return nil, err
@@ -116,5 +248,8 @@ func ValidateRefreshToken(tokenString string) (*JWTClaims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if err := i.checkPolicy(claims); err != nil {
+		return nil, err
+	}
 	return claims, nil
 }
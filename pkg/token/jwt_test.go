@@ -0,0 +1,163 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestValidateRejectsUnexpectedSigningMethods(t *testing.T) {
+	issuer := NewIssuer([]byte("access-secret"), []byte("refresh-secret"), Policies{}, nil)
+	claims := JWTClaims{
+		UserID:           "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))},
+	}
+
+	sign := func(method jwt.SigningMethod, key interface{}) string {
+		t.Helper()
+		s, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	validateAccess := func(s string) (*JWTClaims, error) {
+		return issuer.ValidateAccessToken(context.Background(), s)
+	}
+	tests := []struct {
+		name     string
+		validate func(string) (*JWTClaims, error)
+		token    string
+	}{
+		{"access none", validateAccess, sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType)},
+		{"access hs512", validateAccess, sign(jwt.SigningMethodHS512, []byte("access-secret"))},
+		{"refresh none", issuer.ValidateRefreshToken, sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType)},
+		{"refresh hs384", issuer.ValidateRefreshToken, sign(jwt.SigningMethodHS384, []byte("refresh-secret"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.validate(tt.token); err == nil {
+				t.Fatal("token signed with an unexpected algorithm was accepted")
+			}
+		})
+	}
+
+	// HS256은 그대로 허용
+	if _, err := validateAccess(sign(jwt.SigningMethodHS256, []byte("access-secret"))); err != nil {
+		t.Fatalf("HS256 access token rejected: %v", err)
+	}
+}
+
+func TestIssuerSetPoliciesAppliesToNextToken(t *testing.T) {
+	issuer := NewIssuer([]byte("access-secret"), []byte("refresh-secret"), Policies{
+		Default: Policy{AccessTTL: time.Minute, RefreshTTL: time.Hour},
+	}, nil)
+	s := Session{UserID: "user-1", AuthTime: time.Now()}
+
+	before, err := issuer.GenerateAccessToken(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer.SetPolicies(Policies{Default: Policy{AccessTTL: time.Hour, RefreshTTL: time.Hour, Issuer: "auth"}})
+	after, err := issuer.GenerateAccessToken(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ExpiresAt.After(before.ExpiresAt.Add(30 * time.Minute)) {
+		t.Fatalf("expiry after SetPolicies = %v, before = %v", after.ExpiresAt, before.ExpiresAt)
+	}
+
+	// 새 정책의 iss 검증은 이전 정책으로 발급한 토큰에도 적용
+	if _, err := issuer.ValidateAccessToken(context.Background(), before.Token); err == nil {
+		t.Fatal("token without the new issuer claim was accepted")
+	}
+	claims, err := issuer.ValidateAccessToken(context.Background(), after.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != "user-1" || claims.Issuer != "auth" {
+		t.Fatalf("claims = %+v", claims)
+	}
+
+	// 다른 서명 키를 쓰는 발급기의 토큰은 거부
+	other := NewIssuer([]byte("other-secret"), []byte("refresh-secret"), Policies{Default: Policy{AccessTTL: time.Minute}}, nil)
+	if _, err := other.ValidateAccessToken(context.Background(), after.Token); err == nil {
+		t.Fatal("token signed with another key was accepted")
+	}
+}
+
+// revocationList는 종료된 세션 ID 집합입니다.
+type revocationList struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (r *revocationList) IsSessionRevoked(_ context.Context, sessionID string) (bool, error) {
+	return r.revoked[sessionID], r.err
+}
+
+func TestValidateAccessTokenRejectsRevokedSession(t *testing.T) {
+	revoked := &revocationList{revoked: map[string]bool{}}
+	issuer := NewIssuer([]byte("access-secret"), []byte("refresh-secret"), Policies{
+		Default: Policy{AccessTTL: time.Minute, RefreshTTL: time.Hour},
+	}, revoked)
+	ctx := context.Background()
+
+	sid, err := NewSessionID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	access, err := issuer.GenerateAccessToken(Session{ID: sid, UserID: "user-1", AuthTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := issuer.ValidateAccessToken(ctx, access.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.SessionID != sid {
+		t.Fatalf("sid = %q, want %q", claims.SessionID, sid)
+	}
+
+	revoked.revoked[sid] = true
+	if _, err := issuer.ValidateAccessToken(ctx, access.Token); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("ValidateAccessToken = %v, want ErrSessionRevoked", err)
+	}
+
+	// 종료 여부를 확인할 수 없으면 허용하지 않음
+	revoked.revoked[sid] = false
+	revoked.err = errors.New("redis: connection refused")
+	if _, err := issuer.ValidateAccessToken(ctx, access.Token); err == nil {
+		t.Fatal("token accepted while the revocation list was unavailable")
+	}
+}
+
+func TestRefreshTokenKeepsSessionIDAcrossRotation(t *testing.T) {
+	issuer := NewIssuer([]byte("access-secret"), []byte("refresh-secret"), Policies{
+		Default: Policy{AccessTTL: time.Minute, RefreshTTL: time.Hour},
+	}, nil)
+	refresh, err := issuer.GenerateRefreshToken(Session{ID: "session-1", UserID: "user-1", AuthTime: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := issuer.ValidateRefreshToken(refresh.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := SessionFromClaims(claims); s.ID != "session-1" || s.UserID != "user-1" {
+		t.Fatalf("session from refresh token = %+v", s)
+	}
+}
+
+func TestMaxAccessTTL(t *testing.T) {
+	issuer := NewIssuer(nil, nil, Policies{
+		Default: Policy{AccessTTL: 15 * time.Minute},
+		Clients: map[string]Policy{"web": {AccessTTL: time.Hour}, "mobile": {}},
+	}, nil)
+	if got := issuer.MaxAccessTTL(); got != time.Hour {
+		t.Fatalf("MaxAccessTTL = %v, want 1h", got)
+	}
+}
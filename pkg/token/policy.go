@@ -0,0 +1,85 @@
+// pkg/token/policy.go
+// 이 파일은 토큰 유효 기간, 세션 수명, 발급자/대상 클레임을 정하는 발급 정책과 클라이언트별 재정의를 정의합니다.
+package token
+
+import (
+	"crypto/subtle"
+	"errors"
+	"time"
+)
+
+// Policy는 토큰 발급 정책입니다. 0인 기간은 제한하지 않음을 뜻합니다 (AccessTTL, RefreshTTL 제외).
+type Policy struct {
+	AccessTTL       time.Duration // 액세스 토큰 유효 기간
+	RefreshTTL      time.Duration // 리프레시 토큰 유효 기간 (갱신할 때마다 새로 시작)
+	SessionLifetime time.Duration // 로그인 시각부터 세션이 유지되는 최대 기간 (갱신해도 연장되지 않음)
+	IdleTimeout     time.Duration // 토큰을 갱신하지 않고 세션이 유지되는 최대 기간
+	Issuer          string        // iss 클레임 (비어 있으면 생략하고 검증하지 않음)
+	Audience        []string      // aud 클레임 (비어 있으면 생략하고 검증하지 않음)
+	Secret          string        // 클라이언트 인증 비밀 값 (클라이언트별 정책에만 사용, 기본 정책에 병합하지 않음)
+}
+
+// Policies는 기본 정책과 클라이언트별 재정의입니다.
+type Policies struct {
+	Default Policy
+	Clients map[string]Policy // 키: 클라이언트 ID, 값의 0인 필드는 기본 정책을 따름
+}
+
+// For는 clientID에 적용할 정책입니다. 등록되지 않은 클라이언트는 기본 정책을 사용합니다.
+func (p Policies) For(clientID string) Policy {
+	policy := p.Default
+	override, ok := p.Clients[clientID]
+	if !ok {
+		return policy
+	}
+	if override.AccessTTL > 0 {
+		policy.AccessTTL = override.AccessTTL
+	}
+	if override.RefreshTTL > 0 {
+		policy.RefreshTTL = override.RefreshTTL
+	}
+	if override.SessionLifetime > 0 {
+		policy.SessionLifetime = override.SessionLifetime
+	}
+	if override.IdleTimeout > 0 {
+		policy.IdleTimeout = override.IdleTimeout
+	}
+	if override.Issuer != "" {
+		policy.Issuer = override.Issuer
+	}
+	if len(override.Audience) > 0 {
+		policy.Audience = override.Audience
+	}
+	return policy
+}
+
+// ErrInvalidClient는 등록되지 않은 클라이언트이거나 클라이언트 비밀 값이 맞지 않을 때 반환됩니다.
+var ErrInvalidClient = errors.New("invalid client")
+
+// Authenticate는 clientID와 secret으로 클라이언트를 인증합니다.
+// 빈 clientID는 기본 정책을 쓰는 익명 클라이언트로 허용하고, 등록된 클라이언트는 비밀 값이 일치해야 합니다.
+// 요청의 client_id만으로 다른 클라이언트의 (더 느슨한) 정책을 고를 수 없도록 합니다.
+func (p Policies) Authenticate(clientID, secret string) error {
+	if clientID == "" {
+		return nil
+	}
+	client, ok := p.Clients[clientID]
+	if !ok || client.Secret == "" {
+		return ErrInvalidClient
+	}
+	if subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret)) != 1 {
+		return ErrInvalidClient
+	}
+	return nil
+}
+
+// expiry는 now부터 ttl 뒤의 만료 시각입니다. 세션 종료 시각(로그인 시각 + SessionLifetime)을 넘지 않습니다.
+func (p Policy) expiry(now, authTime time.Time, ttl time.Duration) time.Time {
+	expiresAt := now.Add(ttl)
+	if p.SessionLifetime > 0 && !authTime.IsZero() {
+		if end := authTime.Add(p.SessionLifetime); end.Before(expiresAt) {
+			expiresAt = end
+		}
+	}
+	return expiresAt
+}
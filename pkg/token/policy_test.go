@@ -0,0 +1,44 @@
+package token
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPoliciesAuthenticate(t *testing.T) {
+	p := Policies{Clients: map[string]Policy{
+		"mobile": {Secret: "mobile-secret"},
+		"legacy": {}, // 비밀 값 없이 등록된 클라이언트는 인증할 수 없음
+	}}
+	tests := []struct {
+		name     string
+		clientID string
+		secret   string
+		wantErr  bool
+	}{
+		{"anonymous", "", "", false},
+		{"registered", "mobile", "mobile-secret", false},
+		{"wrong secret", "mobile", "guess", true},
+		{"missing secret", "mobile", "", true},
+		{"unknown client", "web-admin", "", true},
+		{"client without secret", "legacy", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := p.Authenticate(tt.clientID, tt.secret)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Authenticate(%q, %q) = %v, wantErr %v", tt.clientID, tt.secret, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidClient) {
+				t.Fatalf("error = %v, want ErrInvalidClient", err)
+			}
+		})
+	}
+}
+
+func TestPoliciesForDoesNotInheritSecret(t *testing.T) {
+	p := Policies{Default: Policy{Secret: "default"}, Clients: map[string]Policy{"mobile": {Secret: "mobile-secret"}}}
+	if got := p.For("mobile").Secret; got != "default" {
+		t.Fatalf("For merged client secret into the policy: %q", got)
+	}
+}
@@ -0,0 +1,146 @@
+// pkg/tracing/tracing.go
+// 이 파일은 OpenTelemetry 트레이서 프로바이더와 W3C Trace Context 전파 설정을 정의합니다.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aquaheyday/go-auth-service/pkg/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// 익스포터 종류
+const (
+	ExporterNone   = "none"   // 스팬을 내보내지 않음 (전파만 수행)
+	ExporterOTLP   = "otlp"   // OTLP/gRPC 수집기로 전송
+	ExporterStdout = "stdout" // 표준 출력에 JSON으로 기록 (로컬 개발용)
+	ExporterFile   = "file"   // 파일에 JSON으로 기록 (로컬 개발용)
+)
+
+// Config는 트레이싱 설정입니다.
+type Config struct {
+	Exporter     string  // none, otlp, stdout, file
+	OTLPEndpoint string  // 비어 있으면 OTEL_EXPORTER_OTLP_ENDPOINT 또는 localhost:4317
+	OTLPInsecure bool    // OTLP 수집기와 평문 통신
+	FilePath     string  // file 익스포터 출력 경로
+	SampleRatio  float64 // 루트 스팬 샘플링 비율 (0~1, 부모 스팬의 결정은 그대로 따름)
+	ServiceName  string
+	Version      string
+}
+
+// Init은 전역 TracerProvider와 전파기를 설정하고, 종료 시 버퍼를 비우는 함수를 반환합니다.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	// 익스포터와 관계없이 수신한 traceparent는 다음 호출로 전파
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if cfg.Exporter == "" || cfg.Exporter == ExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch cfg.Exporter {
+	case ExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterFile:
+		if cfg.FilePath == "" {
+			return nil, errors.New("tracing: file exporter requires a file path")
+		}
+		f, ferr := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if ferr != nil {
+			return nil, fmt.Errorf("tracing: open trace file: %w", ferr)
+		}
+		closer = f
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: create %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("tracing: build resource: %w", err)
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio < 0 || ratio > 1 {
+		ratio = 1
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// Start는 전역 TracerProvider의 tracer로 스팬을 시작합니다.
+//
+//	ctx, span := tracing.Start(ctx, "internal/usecase", "SignupUseCase.SignUp")
+//	defer span.End()
+func Start(ctx context.Context, tracer, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationPrefix+tracer).Start(ctx, name, opts...)
+}
+
+// StartClient는 외부 시스템(DB, Redis, 메일/SMS 프로바이더) 호출 스팬을 시작합니다.
+func StartClient(ctx context.Context, tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Start(ctx, tracer, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// instrumentationPrefix는 tracer 이름에 붙는 모듈 경로입니다.
+const instrumentationPrefix = "github.com/aquaheyday/go-auth-service/"
+
+// End는 err가 있으면 스팬에 기록하고 스팬을 종료합니다.
+// 에러 메시지의 이메일, 전화번호는 가려서 기록합니다.
+//
+//	defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		msg := logger.Redact(err.Error())
+		span.RecordError(errors.New(msg))
+		span.SetStatus(codes.Error, msg)
+	}
+	span.End()
+}